@@ -65,25 +65,34 @@ func TestSplitRepeat(t *testing.T) {
 	}{
 		{
 			name: "SplitX",
-			got:  s.SplitX(5, 2), // (10+2)*5 = 60
+			got:  s.SplitX(5, 2), // 60-2*4 = 52 = 10+10+11+10+11
 			want: Slice[int]{
 				XYWH(-30, -30, 10, 60),
 				XYWH(-18, -30, 10, 60),
-				XYWH(-6, -30, 10, 60),
-				XYWH(6, -30, 10, 60),
-				XYWH(18, -30, 10, 60),
+				XYWH(-6, -30, 11, 60),
+				XYWH(7, -30, 10, 60),
+				XYWH(19, -30, 11, 60),
 			},
 		},
 		{
 			name: "Split",
-			got:  s.Split(3, 2, 10, 10), // (10+10)*3 = 60, (20+10)*2 = 60
+			got:  s.Split(3, 2, 10, 10), // 60-10*2 = 40 = 13+13+14, 60-10 = 50 = 25+25
 			want: Slice[int]{
-				XYWH(-30, -30, 20, 10),
-				XYWH(-10, -30, 20, 10),
-				XYWH(10, -30, 20, 10),
-				XYWH(-30, 0, 20, 10),
-				XYWH(-10, 0, 20, 10),
-				XYWH(10, 0, 20, 10),
+				XYWH(-30, -30, 13, 25),
+				XYWH(-7, -30, 13, 25),
+				XYWH(16, -30, 14, 25),
+				XYWH(-30, 5, 13, 25),
+				XYWH(-7, 5, 13, 25),
+				XYWH(16, 5, 14, 25),
+			},
+		},
+		{
+			name: "Layout Split",
+			got:  Layout[int]{RoundHalfEven}.Split(WH(100, 10), 3, 1, 0, 0), // 33.3, 66.7
+			want: Slice[int]{
+				XYWH(0, 0, 33, 10),
+				XYWH(33, 0, 34, 10),
+				XYWH(67, 0, 33, 10),
 			},
 		},
 		{
@@ -99,9 +108,242 @@ func TestSplitRepeat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			slices.EqualFunc(tt.got, tt.want, func(a, b Node[int]) bool {
+			if !slices.EqualFunc(tt.got, tt.want, func(a, b Node[int]) bool {
+				return a.Bounds().Eq(b.Bounds())
+			}) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitCover(t *testing.T) {
+	s := WH(100, 50)
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{
+			name: "Fit wide",
+			got:  WH(4, 1).Fit(s, .5, .5),
+			want: XYWH(0, 13, 100, 25),
+		},
+		{
+			name: "Fit tall",
+			got:  WH(1, 2).Fit(s, 1, 0),
+			want: XYWH(75, 0, 25, 50),
+		},
+		{
+			name: "Fit rounds down",
+			got:  WithAspect(16.0/9, WH(100, 100), 0, 0),
+			want: XYWH(0, 0, 100, 56),
+		},
+		{
+			name: "Cover",
+			got:  WH(1, 1).Cover(s, .5, .5),
+			want: XYWH(0, -25, 100, 100),
+		},
+		{
+			name: "Cover rounds up",
+			got:  WithAspectCover(16.0/9, WH(100, 100), 0, 0),
+			want: XYWH(0, 0, 178, 100),
+		},
+		{
+			name: "Empty",
+			got:  WH(0, 5).Fit(s, 0, 0),
+			want: XYWH(0, 0, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRounding(t *testing.T) {
+	s := WH(5, 5)
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{
+			name: "Trunc",
+			got:  Layout[int]{}.CenterOf(WH(2, 2), s),
+			want: XYWH(1, 1, 2, 2),
+		},
+		{
+			name: "Ceil",
+			got:  Layout[int]{RoundCeil}.CenterOf(WH(2, 2), s),
+			want: XYWH(2, 2, 2, 2),
+		},
+		{
+			name: "Cut half even",
+			got:  func() *Rect[int] { l, _ := Layout[int]{RoundHalfEven}.CutXByRate(WH(7, 1), .5); return l }(),
+			want: WH(4, 1),
+		},
+		{
+			name: "Snap",
+			got:  XYXY(3, 7, 13, 18).Snap(5),
+			want: XYXY(5, 5, 15, 20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitNarrow(t *testing.T) {
+	cells := WH[int16](2000, 10).SplitX(20, 0)
+	if got, want := cells[19].Bounds(), XYWH[int16](1900, 0, 100, 10); !got.Eq(want) {
+		t.Errorf("last cell = %v, want %v", got, want)
+	}
+}
+
+func TestSplitTiles(t *testing.T) {
+	for w := 0; w <= 40; w++ {
+		for n := 1; n <= 7; n++ {
+			for gap := 0; gap <= 3; gap++ {
+				if gap*(n-1) > w {
+					continue
+				}
+				r := XYWH(-3, 5, w, 9)
+				cells := r.SplitX(n, gap)
+				x := r.Min.X
+				for i, c := range cells {
+					b := c.Bounds()
+					if b.Min.X != x || b.Min.Y != r.Min.Y || b.Max.Y != r.Max.Y {
+						t.Fatalf("w=%d n=%d gap=%d: cell %d = %v, want Min.X %d", w, n, gap, i, b, x)
+					}
+					if d := b.Dx() - (w-gap*(n-1))/n; d != 0 && d != 1 {
+						t.Fatalf("w=%d n=%d: cell %d width %d is not balanced", w, n, i, b.Dx())
+					}
+					x = b.Max.X + gap
+				}
+				if x-gap != r.Max.X {
+					t.Errorf("w=%d n=%d gap=%d: cells end at %d, want %d", w, n, gap, x-gap, r.Max.X)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitSizes(t *testing.T) {
+	s := WH(100, 10)
+	tests := []struct {
+		name      string
+		got, want Slice[int]
+	}{
+		{
+			name: "Weights",
+			got:  s.SplitXWeights([]float64{1, 1, 1}, 2), // 96 = 32*3
+			want: Slice[int]{
+				XYWH(0, 0, 32, 10),
+				XYWH(34, 0, 32, 10),
+				XYWH(68, 0, 32, 10),
+			},
+		},
+		{
+			name: "Sizes",
+			got:  s.SplitXSizes([]Size{Fixed(20), Fr(2), Fr(1)}, 0), // 20 | 53.3 | 26.7
+			want: Slice[int]{
+				XYWH(0, 0, 20, 10),
+				XYWH(20, 0, 53, 10),
+				XYWH(73, 0, 27, 10),
+			},
+		},
+		{
+			name: "Rate",
+			got:  WH(10, 100).SplitYSizes([]Size{Rate(.25), Fixed(10)}, 0),
+			want: Slice[int]{
+				XYWH(0, 0, 10, 25),
+				XYWH(0, 25, 10, 10),
+			},
+		},
+		{
+			name: "Fixed overflow",
+			got:  s.SplitXSizes([]Size{Fixed(200), Fr(1)}, 0),
+			want: Slice[int]{
+				XYWH(0, 0, 100, 10),
+				XYWH(100, 0, 0, 10),
+			},
+		},
+		{
+			name: "Fixed overflow after Fr",
+			got:  s.SplitXSizes([]Size{Fr(1), Fixed(200)}, 0),
+			want: Slice[int]{
+				XYWH(0, 0, 0, 10),
+				XYWH(0, 0, 100, 10),
+			},
+		},
+		{
+			name: "Rate overflow",
+			got:  s.SplitXSizes([]Size{Rate(.8), Rate(.8)}, 0),
+			want: Slice[int]{
+				XYWH(0, 0, 80, 10),
+				XYWH(80, 0, 20, 10),
+			},
+		},
+		{
+			name: "Fixed underflow",
+			got:  s.SplitXSizes([]Size{Fixed(20), Fixed(30)}, 10),
+			want: Slice[int]{
+				XYWH(0, 0, 20, 10),
+				XYWH(30, 0, 30, 10),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.EqualFunc(tt.got, tt.want, func(a, b Node[int]) bool {
 				return a.Bounds().Eq(b.Bounds())
-			})
+			}) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCutSide(t *testing.T) {
+	s := XYWH(0, 0, 100, 50)
+	hud := s.Clone()
+	top := hud.TakeTop(10, 2)
+	left := hud.TakeLeft(20, 0)
+	bottom := hud.TakeByRate(Bottom, .5, 0)
+	right, _ := hud.CutRight(200)
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "CutLeft", got: first(s.CutLeft(30)), want: XYWH(0, 0, 30, 50)},
+		{name: "CutRight", got: first(s.CutRight(30)), want: XYWH(70, 0, 30, 50)},
+		{name: "CutTop", got: first(s.CutTop(20)), want: XYWH(0, 0, 100, 20)},
+		{name: "CutBottom rest", got: second(s.CutBottom(20)), want: XYWH(0, 0, 100, 30)},
+		{name: "CutByRate", got: first(s.CutByRate(Right, .25)), want: XYWH(75, 0, 25, 50)},
+		{name: "TakeTop", got: top, want: XYWH(0, 0, 100, 10)},
+		{name: "TakeLeft", got: left, want: XYWH(0, 12, 20, 38)},
+		{name: "TakeByRate", got: bottom, want: XYWH(20, 31, 80, 19)},
+		{name: "Rest", got: hud, want: XYWH(20, 12, 80, 19)},
+		{name: "CutRight clamp", got: right, want: XYWH(20, 12, 80, 19)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
 		})
 	}
 }
+
+func first[T any](a, _ T) T  { return a }
+func second[T any](_, b T) T { return b }
@@ -0,0 +1,126 @@
+package align
+
+import "github.com/eihigh/ng"
+
+// Baseliner is implemented by nodes that have a text baseline, such as
+// [*TextLine] and [*TextBlock].
+type Baseliner[S ng.Scalar] interface {
+	Node[S]
+	// Baseline returns the y coordinate of the baseline.
+	Baseline() S
+}
+
+// Baseline returns the baseline of n. A [Baseliner] reports its own
+// baseline, a [Slice] has the baseline of its first node, and any other node
+// has its baseline at the bottom, like an image set in text.
+func Baseline[S ng.Scalar](n Node[S]) S {
+	switch n := n.(type) {
+	case Baseliner[S]:
+		return n.Baseline()
+	case Slice[S]:
+		if len(n) > 0 {
+			return Baseline(n[0])
+		}
+	}
+	return n.Bounds().Max.Y
+}
+
+// Baselined attaches a baseline to a node that has none, such as an icon or
+// a text box measured elsewhere.
+type Baselined[S ng.Scalar] struct {
+	Node[S]
+	// Ascent is the distance from the top of the node to its baseline.
+	Ascent S
+}
+
+// WithBaseline returns n with its baseline at ascent below its top.
+func WithBaseline[S ng.Scalar](n Node[S], ascent S) *Baselined[S] {
+	return &Baselined[S]{Node: n, Ascent: ascent}
+}
+
+// Baseline implements [Baseliner] interface.
+func (b *Baselined[S]) Baseline() S {
+	return b.Bounds().Min.Y + b.Ascent
+}
+
+// baselineShift returns the vertical offset that moves the baseline of n to
+// the baseline of target.
+func baselineShift[S ng.Scalar](n, target Node[S]) Point[S] {
+	return Point[S]{0, Baseline(target) - Baseline(n)}
+}
+
+// AlignBaseline moves r vertically so that its bottom, its baseline, is on
+// the baseline of target.
+func (r *Rect[S]) AlignBaseline(target Node[S]) *Rect[S] {
+	return r.Add(baselineShift(r, target))
+}
+
+// StackXBaseline stacks r horizontally against target like [Rect.StackX],
+// with its bottom on the baseline of target.
+func (r *Rect[S]) StackXBaseline(target Node[S], tax float64) *Rect[S] {
+	return r.StackX(target, tax, 0).AlignBaseline(target)
+}
+
+// AlignBaseline moves the slice vertically so that its baseline is on the
+// baseline of target.
+func (s Slice[S]) AlignBaseline(target Node[S]) Slice[S] {
+	s.Shift(baselineShift(s, target))
+	return s
+}
+
+// StackXBaseline stacks the slice horizontally against target like
+// [Slice.StackX], with its baseline on the baseline of target.
+func (s Slice[S]) StackXBaseline(target Node[S], tax float64) Slice[S] {
+	return s.StackX(target, tax, 0).AlignBaseline(target)
+}
+
+// AlignBaselines moves the nodes of the slice vertically so that they share
+// a baseline. The baseline is placed as high as possible without moving any
+// node above the top of the slice.
+func (s Slice[S]) AlignBaselines() Slice[S] {
+	alignBaselines(s, s.Bounds().Min.Y)
+	return s
+}
+
+// AlignBaseline moves the map vertically so that its bottom is on the
+// baseline of target.
+func (m Map[S]) AlignBaseline(target Node[S]) Map[S] {
+	m.Shift(baselineShift(m, target))
+	return m
+}
+
+// StackXBaseline stacks the map horizontally against target like
+// [Map.StackX], with its bottom on the baseline of target.
+func (m Map[S]) StackXBaseline(target Node[S], tax float64) Map[S] {
+	return m.StackX(target, tax, 0).AlignBaseline(target)
+}
+
+// AlignBaselines moves the nodes of the map vertically so that they share a
+// baseline, as with [Slice.AlignBaselines].
+func (m Map[S]) AlignBaselines() Map[S] {
+	s := make(Slice[S], 0, len(m))
+	for _, n := range m {
+		s = append(s, n)
+	}
+	alignBaselines(s, m.Bounds().Min.Y)
+	return m
+}
+
+// alignBaselines moves the nodes of s so that they share the baseline at the
+// largest ascent below top.
+func alignBaselines[S ng.Scalar](s Slice[S], top S) {
+	if len(s) == 0 {
+		return
+	}
+	var base S
+	for i, n := range s {
+		a := Baseline(n) - n.Bounds().Min.Y
+		if i == 0 || a > base {
+			base = a
+		}
+	}
+	base += top
+	for _, n := range s {
+		n.Shift(Point[S]{0, base - Baseline(n)})
+	}
+}
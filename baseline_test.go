@@ -0,0 +1,65 @@
+package align
+
+import "testing"
+
+func TestBaseline(t *testing.T) {
+	text := func(y, h, ascent int) *TextLine[int] {
+		return &TextLine[int]{Line: XYWH(0, y, 50, h), Ascent: ascent}
+	}
+
+	small, big := text(0, 12, 9), text(0, 24, 18)
+	icon := WH(8, 8)
+	row := Slice[int]{small, big, icon}.AlignBaselines()
+
+	line := text(100, 20, 15)
+	moved := Slice[int]{text(0, 12, 9), WH(10, 10)}
+	moved.AlignBaseline(line)
+
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "Rect AlignBaseline", got: WH(10, 10).AlignBaseline(line), want: XYWH(0, 105, 10, 10)},
+		{name: "Rect StackXBaseline", got: WH(10, 10).StackXBaseline(line, 1), want: XYWH(50, 105, 10, 10)},
+		{name: "AlignBaselines small", got: small.Line, want: XYWH(0, 9, 50, 12)},
+		{name: "AlignBaselines big", got: big.Line, want: XYWH(0, 0, 50, 24)},
+		{name: "AlignBaselines rect", got: icon, want: XYWH(0, 10, 8, 8)},
+		{name: "AlignBaselines bounds", got: row.Bounds(), want: XYWH(0, 0, 50, 24)},
+		{name: "Slice AlignBaseline", got: moved.Bounds(), want: XYWH(0, 106, 50, 12)},
+		{name: "Baselined", got: Slice[int]{WithBaseline[int](WH(10, 10), 7)}.AlignBaseline(line).Bounds(), want: XYWH(0, 108, 10, 10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapperAlignBaselines(t *testing.T) {
+	stack := func(a, b *Rect[int]) { a.StackX(b, 1, 0) }
+	wrap := func(a, b *Rect[int]) { a.StackY(b, 0, 1) }
+	w := NewWrapper(WH(80, 100), 0, 0, stack, wrap)
+	a, b, c := WH(30, 24), WH(30, 12), WH(30, 12)
+	w.AddWithBaseline(a, 18)
+	w.AddWithBaseline(b, 4)
+	w.AddWithBaseline(c, 9)
+	w.AlignBaselines()
+
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "tallest ascent", got: a, want: XYWH(0, 0, 30, 24)},
+		{name: "aligned", got: b, want: XYWH(30, 14, 30, 12)},
+		{name: "next line pushed", got: c, want: XYWH(0, 26, 30, 12)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package align
+
+import (
+	"math"
+	"sort"
+
+	"github.com/eihigh/ng"
+)
+
+// BreakpointBy selects the measure of the root rectangle that
+// [Breakpoints] compare with their thresholds.
+type BreakpointBy int
+
+const (
+	// BreakpointWidth measures the width.
+	BreakpointWidth BreakpointBy = iota
+	// BreakpointHeight measures the height.
+	BreakpointHeight
+	// BreakpointAspect measures the aspect ratio, width / height.
+	BreakpointAspect
+)
+
+// breakpoint is a layout variant used from the threshold min.
+type breakpoint[S ng.Scalar] struct {
+	min    float64
+	layout func(root *Rect[S]) Node[S]
+}
+
+// Breakpoints chooses one of several layout functions by the size of the root
+// rectangle, such as a portrait, a 4:3 and a 16:9 variant of a screen.
+type Breakpoints[S ng.Scalar] struct {
+	by BreakpointBy
+	// hysteresis is how far the measure must pass a threshold to leave the
+	// current variant.
+	hysteresis float64
+	points     []breakpoint[S]
+	current    int
+}
+
+// NewBreakpoints creates a Breakpoints that measures the root by by. The
+// current variant is kept until the measure passes one of its thresholds by
+// more than hysteresis, so that resizing around a threshold does not flicker.
+func NewBreakpoints[S ng.Scalar](by BreakpointBy, hysteresis float64) *Breakpoints[S] {
+	return &Breakpoints[S]{by: by, hysteresis: hysteresis, current: -1}
+}
+
+// Add adds a layout variant used when the measure is at least lo. The
+// variant with the smallest lo is also used below it. It returns b for
+// chaining.
+func (b *Breakpoints[S]) Add(lo float64, layout func(root *Rect[S]) Node[S]) *Breakpoints[S] {
+	i := sort.Search(len(b.points), func(i int) bool { return b.points[i].min > lo })
+	b.points = append(b.points, breakpoint[S]{})
+	copy(b.points[i+1:], b.points[i:])
+	b.points[i] = breakpoint[S]{min: lo, layout: layout}
+	b.current = -1
+	return b
+}
+
+// Len returns the number of variants.
+func (b *Breakpoints[S]) Len() int {
+	return len(b.points)
+}
+
+// Current returns the index of the variant chosen by the last call to
+// [Breakpoints.Select], in ascending order of thresholds, or -1.
+func (b *Breakpoints[S]) Current() int {
+	return b.current
+}
+
+// measure returns the measure of root.
+func (b *Breakpoints[S]) measure(root *Rect[S]) float64 {
+	switch b.by {
+	case BreakpointHeight:
+		return float64(root.Dy())
+	case BreakpointAspect:
+		if root.Dy() == 0 {
+			return math.Inf(1)
+		}
+		return float64(root.Dx()) / float64(root.Dy())
+	default:
+		return float64(root.Dx())
+	}
+}
+
+// Select chooses the variant for root and returns its index, or -1 if there
+// are no variants.
+func (b *Breakpoints[S]) Select(root *Rect[S]) int {
+	if len(b.points) == 0 {
+		return -1
+	}
+	v := b.measure(root)
+	if c := b.current; c >= 0 {
+		lo, hi := math.Inf(-1), math.Inf(1)
+		if c > 0 {
+			lo = b.points[c].min - b.hysteresis
+		}
+		if c+1 < len(b.points) {
+			hi = b.points[c+1].min + b.hysteresis
+		}
+		if lo <= v && v < hi {
+			return c
+		}
+	}
+	i := sort.Search(len(b.points), func(i int) bool { return b.points[i].min > v })
+	b.current = max(0, i-1)
+	return b.current
+}
+
+// Layout selects the variant for root and returns the node tree it lays
+// out, or nil if there are no variants.
+func (b *Breakpoints[S]) Layout(root *Rect[S]) Node[S] {
+	i := b.Select(root)
+	if i < 0 {
+		return nil
+	}
+	return b.points[i].layout(root)
+}
+
+// Blend lays out root with the variants i and j and interpolates between
+// them at t with [Tween], for animating the switch between variants. Nodes
+// only in one variant grow from or shrink into their centers.
+//
+// An index of -1, as returned by [Breakpoints.Current] before the first
+// selection, stands for no variant: Blend then returns the layout of the other
+// variant without blending, or [Breakpoints.Layout] of root if both are -1.
+// Any other index out of range panics, like slice indexing.
+func (b *Breakpoints[S]) Blend(root *Rect[S], i, j int, t float64) Node[S] {
+	switch {
+	case i == -1 && j == -1:
+		return b.Layout(root)
+	case i == -1:
+		return b.points[j].layout(root)
+	case j == -1:
+		return b.points[i].layout(root)
+	}
+	tw := Tween[S]{
+		From: b.points[i].layout(root),
+		To:   b.points[j].layout(root),
+		AX:   0.5,
+		AY:   0.5,
+	}
+	return tw.At(t)
+}
@@ -0,0 +1,62 @@
+package align
+
+import "testing"
+
+func TestBreakpoints(t *testing.T) {
+	portrait := func(root *Rect[int]) Node[int] {
+		return Map[int]{"menu": XYWH(0, root.Max.Y-100, root.Dx(), 100)}
+	}
+	landscape := func(root *Rect[int]) Node[int] {
+		return Map[int]{"menu": XYWH(0, 0, 200, root.Dy())}
+	}
+	b := NewBreakpoints[int](BreakpointAspect, 0.1).
+		Add(1, landscape).
+		Add(0, portrait)
+
+	steps := []struct {
+		root *Rect[int]
+		want int
+	}{
+		{root: WH(600, 800), want: 0},
+		{root: WH(1000, 800), want: 1},
+		{root: WH(780, 800), want: 1}, // within hysteresis
+		{root: WH(700, 800), want: 0},
+		{root: WH(840, 800), want: 0}, // within hysteresis
+		{root: WH(900, 800), want: 1},
+	}
+	for _, s := range steps {
+		if got := b.Select(s.root); got != s.want {
+			t.Errorf("Select(%v) = %d, want %d", s.root, got, s.want)
+		}
+	}
+
+	root := WH(800, 600)
+	if got, want := b.Layout(root).(Map[int])["menu"].Bounds(), XYWH(0, 0, 200, 600); !got.Eq(want) {
+		t.Errorf("Layout = %v, want %v", got, want)
+	}
+	mid := b.Blend(root, 0, 1, 0.5).(Map[int])["menu"].Bounds()
+	if want := XYXY(0, 250, 500, 600); !mid.Eq(want) {
+		t.Errorf("Blend = %v, want %v", mid, want)
+	}
+	if got, want := b.Blend(root, -1, 1, 0.5).(Map[int])["menu"].Bounds(), XYWH(0, 0, 200, 600); !got.Eq(want) {
+		t.Errorf("Blend from -1 = %v, want %v", got, want)
+	}
+	if got, want := b.Blend(root, 0, -1, 0.5).(Map[int])["menu"].Bounds(), XYWH(0, 500, 800, 100); !got.Eq(want) {
+		t.Errorf("Blend to -1 = %v, want %v", got, want)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Blend to 5 did not panic")
+			}
+		}()
+		b.Blend(root, 0, 5, 0.5)
+	}()
+
+	if NewBreakpoints[int](BreakpointWidth, 0).Layout(root) != nil {
+		t.Error("Layout without variants is not nil")
+	}
+	if NewBreakpoints[int](BreakpointWidth, 0).Blend(root, -1, -1, 0.5) != nil {
+		t.Error("Blend without variants is not nil")
+	}
+}
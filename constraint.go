@@ -0,0 +1,260 @@
+package align
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Constraints limits the size of a rectangle. Zero MaxW or MaxH means no
+// maximum, and zero Aspect means no aspect lock. A minimum takes precedence
+// over a smaller maximum.
+type Constraints[S ng.Scalar] struct {
+	MinW, MinH S
+	MaxW, MaxH S
+	// Aspect locks the aspect ratio, width / height.
+	Aspect float64
+}
+
+// ConstraintError reports a rectangle that does not satisfy its constraints.
+type ConstraintError[S ng.Scalar] struct {
+	// Index is the index of the rectangle among the results of the
+	// operation, or -1 for a single rectangle.
+	Index       int
+	Rect        *Rect[S]
+	Constraints Constraints[S]
+}
+
+func (e *ConstraintError[S]) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("align: %v violates constraints %v", e.Rect, e.Constraints)
+	}
+	return fmt.Sprintf("align: %v at index %d violates constraints %v", e.Rect, e.Index, e.Constraints)
+}
+
+// String returns c as "w[min,max] h[min,max] aspect a", omitting the aspect
+// if unlocked.
+func (c Constraints[S]) String() string {
+	s := fmt.Sprintf("w[%v,%v] h[%v,%v]", c.MinW, c.MaxW, c.MinH, c.MaxH)
+	if c.Aspect > 0 {
+		s += fmt.Sprintf(" aspect %v", c.Aspect)
+	}
+	return s
+}
+
+// clampLen limits n to [lo, hi], ignoring hi if zero.
+func clampLen[S ng.Scalar](n, lo, hi S) S {
+	if hi > 0 {
+		n = min(n, hi)
+	}
+	return max(n, lo)
+}
+
+// inLen reports whether n is in [lo, hi], ignoring hi if zero.
+func inLen[S ng.Scalar](n, lo, hi S) bool {
+	return n >= lo && (hi <= 0 || n <= hi)
+}
+
+// Constrain resizes r to satisfy c, keeping its top-left corner. With an
+// aspect lock, the width is kept if possible, otherwise the height.
+func (c Constraints[S]) Constrain(r *Rect[S]) *Rect[S] {
+	w := clampLen(r.Dx(), c.MinW, c.MaxW)
+	h := clampLen(r.Dy(), c.MinH, c.MaxH)
+	if c.Aspect > 0 {
+		h2 := roundTo[S](float64(w)/c.Aspect, RoundHalfEven)
+		if inLen(h2, c.MinH, c.MaxH) {
+			h = h2
+		} else {
+			h = clampLen(h2, c.MinH, c.MaxH)
+			w = clampLen(roundTo[S](float64(h)*c.Aspect, RoundHalfEven), c.MinW, c.MaxW)
+		}
+	}
+	r.Max = r.Min.Add(Point[S]{w, h})
+	return r
+}
+
+// Check returns a [*ConstraintError] with Index -1 if r does not satisfy c.
+// The aspect ratio is allowed to be off by the rounding of integer sizes.
+func (c Constraints[S]) Check(r *Rect[S]) error {
+	if !c.satisfied(r) {
+		return &ConstraintError[S]{Index: -1, Rect: r.Clone(), Constraints: c}
+	}
+	return nil
+}
+
+func (c Constraints[S]) satisfied(r *Rect[S]) bool {
+	w, h := r.Dx(), r.Dy()
+	if !inLen(w, c.MinW, c.MaxW) || !inLen(h, c.MinH, c.MaxH) {
+		return false
+	}
+	if c.Aspect > 0 {
+		tol := 1e-9 * max(1, float64(w))
+		if isInt[S]() {
+			tol = max(1, c.Aspect)
+		}
+		if math.Abs(float64(w)-float64(h)*c.Aspect) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAll returns a [*ConstraintError] for the first rectangle of s that
+// does not satisfy its constraints.
+func checkAll[S ng.Scalar](s Slice[S], cons []Constraints[S]) error {
+	for i, n := range s {
+		if r := n.Bounds(); !cons[i].satisfied(r) {
+			return &ConstraintError[S]{Index: i, Rect: r.Clone(), Constraints: cons[i]}
+		}
+	}
+	return nil
+}
+
+// CutConstrained is like [Rect.Cut] but adjusts n so that the cut and the
+// rest satisfy their constraints along the cut axis. The cut has priority:
+// it keeps its own limits even if the rest cannot satisfy its limits. If a
+// result still violates its constraints, including those across the cut axis
+// and the aspect lock, the results are returned with a [*ConstraintError]
+// whose Index is 0 for the cut and 1 for the rest.
+func (r *Rect[S]) CutConstrained(side Side, n S, cut, rest Constraints[S]) (c, rr *Rect[S], err error) {
+	total, cutMin, cutMax, restMin, restMax := r.Dx(), cut.MinW, cut.MaxW, rest.MinW, rest.MaxW
+	if side == Top || side == Bottom {
+		total, cutMin, cutMax, restMin, restMax = r.Dy(), cut.MinH, cut.MaxH, rest.MinH, rest.MaxH
+	}
+	n = clampLen(n, cutMin, cutMax)
+	if total-n < restMin {
+		n = max(cutMin, total-restMin)
+	}
+	if restMax > 0 && total-n > restMax {
+		n = clampLen(total-restMax, cutMin, cutMax)
+	}
+	c, rr = r.Cut(side, n)
+	return c, rr, checkAll(Slice[S]{c, rr}, []Constraints[S]{cut, rest})
+}
+
+// CutByRateConstrained is like [Rect.CutConstrained] but measures n as a
+// rate (0.0 to 1.0) of r's size.
+func (r *Rect[S]) CutByRateConstrained(side Side, rate float64, cut, rest Constraints[S]) (c, rr *Rect[S], err error) {
+	return r.CutConstrained(side, r.sideLen(side, rate), cut, rest)
+}
+
+// SplitXConstrained divides r into one column per constraint, with xGap
+// spacing between them. The columns share the width equally as far as their
+// minimum and maximum widths allow. If the minimums do not fit, the earlier
+// columns keep theirs and the later ones shrink. A [*ConstraintError]
+// reports the first column that violates its constraints.
+func (r *Rect[S]) SplitXConstrained(cons []Constraints[S], xGap S) (Slice[S], error) {
+	mins, maxs := make([]S, len(cons)), make([]S, len(cons))
+	for i, c := range cons {
+		mins[i], maxs[i] = c.MinW, c.MaxW
+	}
+	s := make(Slice[S], 0, len(cons))
+	x := r.Min.X
+	for _, w := range distribute(r.Dx()-xGap*S(max(0, len(cons)-1)), mins, maxs) {
+		s = append(s, XYXY(x, r.Min.Y, x+w, r.Max.Y))
+		x += w + xGap
+	}
+	return s, checkAll(s, cons)
+}
+
+// SplitYConstrained divides r into one row per constraint, with yGap spacing
+// between them, like [Rect.SplitXConstrained].
+func (r *Rect[S]) SplitYConstrained(cons []Constraints[S], yGap S) (Slice[S], error) {
+	mins, maxs := make([]S, len(cons)), make([]S, len(cons))
+	for i, c := range cons {
+		mins[i], maxs[i] = c.MinH, c.MaxH
+	}
+	s := make(Slice[S], 0, len(cons))
+	y := r.Min.Y
+	for _, h := range distribute(r.Dy()-yGap*S(max(0, len(cons)-1)), mins, maxs) {
+		s = append(s, XYXY(r.Min.X, y, r.Max.X, y+h))
+		y += h + yGap
+	}
+	return s, checkAll(s, cons)
+}
+
+// distribute divides total equally into sizes clamped between mins and
+// maxs, ignoring zero maximums. If the minimums do not fit, the earlier sizes
+// have priority. The remainder of integer divisions goes to the earlier
+// sizes.
+func distribute[S ng.Scalar](total S, mins, maxs []S) []S {
+	sizes := make([]S, len(mins))
+	var sum S
+	for _, m := range mins {
+		sum += max(0, m)
+	}
+	if sum >= total {
+		left := max(0, total)
+		for i, m := range mins {
+			sizes[i] = min(max(0, m), left)
+			left -= sizes[i]
+		}
+		return sizes
+	}
+
+	fixed := make([]bool, len(sizes))
+	for {
+		left := total
+		var open []int
+		for i := range sizes {
+			if fixed[i] {
+				left -= sizes[i]
+			} else {
+				open = append(open, i)
+			}
+		}
+		if len(open) == 0 {
+			return sizes
+		}
+		share := left / S(len(open))
+		// Clamping to the minimums takes space from the other sizes and
+		// clamping to the maximums gives space to them, so only the class
+		// with the larger total violation is fixed in each pass.
+		var under, over []int
+		var diff S
+		for _, i := range open {
+			switch {
+			case share < mins[i]:
+				under = append(under, i)
+				diff += mins[i] - share
+			case maxs[i] > 0 && share > maxs[i]:
+				over = append(over, i)
+				diff -= share - maxs[i]
+			}
+		}
+		if diff >= 0 {
+			for _, i := range under {
+				sizes[i], fixed[i] = mins[i], true
+			}
+		}
+		if diff <= 0 {
+			for _, i := range over {
+				sizes[i], fixed[i] = maxs[i], true
+			}
+		}
+		if len(under)+len(over) > 0 {
+			continue
+		}
+		rem := left - share*S(len(open)) // integer remainder
+		for k, i := range open {
+			sizes[i] = share
+			if isInt[S]() && S(k) < rem {
+				sizes[i]++
+			}
+		}
+		return sizes
+	}
+}
+
+// StackXConstrained constrains r as with [Constraints.Constrain] and stacks
+// it horizontally against target like [Rect.StackX].
+func (r *Rect[S]) StackXConstrained(target Node[S], tax, tay float64, c Constraints[S]) *Rect[S] {
+	return c.Constrain(r).StackX(target, tax, tay)
+}
+
+// StackYConstrained constrains r as with [Constraints.Constrain] and stacks
+// it vertically against target like [Rect.StackY].
+func (r *Rect[S]) StackYConstrained(target Node[S], tax, tay float64, c Constraints[S]) *Rect[S] {
+	return c.Constrain(r).StackY(target, tax, tay)
+}
@@ -0,0 +1,106 @@
+package align
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstrain(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Constraints[int]
+		r    *Rect[int]
+		want *Rect[int]
+		// unsat marks constraints that no rectangle satisfies.
+		unsat bool
+	}{
+		{name: "min", c: Constraints[int]{MinW: 100}, r: XYWH(10, 10, 50, 30), want: XYWH(10, 10, 100, 30)},
+		{name: "max", c: Constraints[int]{MaxW: 40, MaxH: 20}, r: WH(50, 30), want: WH(40, 20)},
+		{name: "aspect keeps width", c: Constraints[int]{Aspect: 2}, r: WH(100, 80), want: WH(100, 50)},
+		{name: "aspect limited height", c: Constraints[int]{MaxH: 40, Aspect: 2}, r: WH(100, 80), want: WH(80, 40)},
+		{name: "min over max", c: Constraints[int]{MinW: 50, MaxW: 30}, r: WH(40, 10), want: WH(50, 10), unsat: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Constrain(tt.r); !got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if err := tt.c.Check(tt.r); (err != nil) != tt.unsat {
+				t.Errorf("Check after Constrain = %v, want error %v", err, tt.unsat)
+			}
+		})
+	}
+}
+
+func TestCutConstrained(t *testing.T) {
+	sidebar, main := Constraints[int]{MinW: 120, MaxW: 300}, Constraints[int]{MinW: 200}
+	tests := []struct {
+		name      string
+		r         *Rect[int]
+		rate      float64
+		cut, rest Constraints[int]
+		want      *Rect[int]
+		errIndex  int // -1 for no error
+	}{
+		{name: "min", r: WH(800, 100), rate: 0.1, cut: sidebar, rest: main, want: WH(120, 100), errIndex: -1},
+		{name: "max", r: WH(800, 100), rate: 0.5, cut: sidebar, rest: main, want: WH(300, 100), errIndex: -1},
+		{name: "rest min", r: WH(400, 100), rate: 0.5, cut: sidebar, rest: main, want: WH(200, 100), errIndex: -1},
+		{name: "cut priority", r: WH(250, 100), rate: 0.2, cut: sidebar, rest: main, want: WH(120, 100), errIndex: 1},
+		{name: "rest max", r: WH(1000, 100), rate: 0.1, cut: Constraints[int]{MaxW: 500}, rest: Constraints[int]{MaxW: 600}, want: WH(400, 100), errIndex: -1},
+		{name: "cross axis", r: WH(800, 100), rate: 0.1, cut: Constraints[int]{MinH: 200}, rest: main, want: WH(80, 100), errIndex: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cut, _, err := tt.r.CutByRateConstrained(Left, tt.rate, tt.cut, tt.rest)
+			if !cut.Eq(tt.want) {
+				t.Errorf("cut = %v, want %v", cut, tt.want)
+			}
+			var ce *ConstraintError[int]
+			switch {
+			case tt.errIndex < 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.errIndex >= 0 && !errors.As(err, &ce):
+				t.Errorf("err = %v, want a ConstraintError", err)
+			case tt.errIndex >= 0 && ce.Index != tt.errIndex:
+				t.Errorf("error index = %d, want %d", ce.Index, tt.errIndex)
+			}
+		})
+	}
+}
+
+func TestSplitConstrained(t *testing.T) {
+	cols, err := WH(310, 100).SplitXConstrained([]Constraints[int]{{MinW: 100}, {}, {MaxW: 50}}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Slice[int]{XYXY(0, 0, 120, 100), XYXY(130, 0, 250, 100), XYXY(260, 0, 310, 100)}
+	for i := range want {
+		if !cols[i].Bounds().Eq(want[i].Bounds()) {
+			t.Errorf("column %d = %v, want %v", i, cols[i], want[i])
+		}
+	}
+
+	mixed, err := WH(120, 10).SplitXConstrained([]Constraints[int]{{MaxW: 30}, {MinW: 80}, {}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range []int{20, 80, 20} {
+		if got := mixed[i].Bounds().Dx(); got != w {
+			t.Errorf("mixed column %d width = %d, want %d", i, got, w)
+		}
+	}
+
+	rows, err := WH(100, 100).SplitYConstrained([]Constraints[int]{{MinH: 60}, {MinH: 60}}, 0)
+	var ce *ConstraintError[int]
+	if !errors.As(err, &ce) || ce.Index != 1 {
+		t.Errorf("err = %v, want a ConstraintError at index 1", err)
+	}
+	if got, want := rows[0].Bounds(), WH(100, 60); !got.Eq(want) {
+		t.Errorf("first row = %v, want %v", got, want)
+	}
+
+	even, _ := WH(10, 10).SplitXConstrained(make([]Constraints[int], 3), 0)
+	if got, want := even[0].Bounds().Dx(), 4; got != want {
+		t.Errorf("remainder: first width = %d, want %d", got, want)
+	}
+}
@@ -3,13 +3,23 @@ package align
 import "github.com/eihigh/ng"
 
 // Node represents a node in a tree structure.
-// The leaf rectangle [Rect] and the container [Slice]
-// and [Map] implement this interface.
+// The leaf rectangle [Rect] and the containers [Slice],
+// [Map] and [Dock] implement this interface.
 type Node[S ng.Scalar] interface {
 	Bounds() *Rect[S]
 	Shift(Point[S])
 }
 
+// place moves n into r. A [*Rect] is resized to r; other nodes are moved so
+// that their bounds start at r's top-left corner.
+func place[S ng.Scalar](n Node[S], r *Rect[S]) {
+	if b, ok := n.(*Rect[S]); ok {
+		*b = *r
+		return
+	}
+	n.Shift(r.Min.Sub(n.Bounds().Min))
+}
+
 // Slice is a slice of nodes that can be aligned and moved together.
 type Slice[S ng.Scalar] []Node[S]
 
@@ -87,6 +97,18 @@ func (s Slice[S]) Clamp(target Node[S]) Slice[S] {
 	return s
 }
 
+// Fit returns the largest rectangle with the aspect ratio of the bounding
+// rectangle that fits within target, positioned at (ax, ay).
+func (s Slice[S]) Fit(target Node[S], ax, ay float64) *Rect[S] {
+	return s.Bounds().Fit(target, ax, ay)
+}
+
+// Cover returns the smallest rectangle with the aspect ratio of the bounding
+// rectangle that covers target, positioned at (ax, ay).
+func (s Slice[S]) Cover(target Node[S], ax, ay float64) *Rect[S] {
+	return s.Bounds().Cover(target, ax, ay)
+}
+
 // Inset returns the bounding rectangle inset by n.
 func (s Slice[S]) Inset(n S) *Rect[S] {
 	return s.Bounds().Inset(n)
@@ -177,6 +199,18 @@ func (m Map[S]) StackY(target Node[S], tax, tay float64) Map[S] {
 	return m.Align(tax, 1-tay, target, tax, tay)
 }
 
+// Fit returns the largest rectangle with the aspect ratio of the bounding
+// rectangle that fits within target, positioned at (ax, ay).
+func (m Map[S]) Fit(target Node[S], ax, ay float64) *Rect[S] {
+	return m.Bounds().Fit(target, ax, ay)
+}
+
+// Cover returns the smallest rectangle with the aspect ratio of the bounding
+// rectangle that covers target, positioned at (ax, ay).
+func (m Map[S]) Cover(target Node[S], ax, ay float64) *Rect[S] {
+	return m.Bounds().Cover(target, ax, ay)
+}
+
 // Inset returns the bounding rectangle inset by n.
 func (m Map[S]) Inset(n S) *Rect[S] {
 	return m.Bounds().Inset(n)
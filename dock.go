@@ -0,0 +1,172 @@
+package align
+
+import "github.com/eihigh/ng"
+
+// Dock arranges nodes along the sides of its bounds. Each node carves its
+// space from the remaining rectangle in the order it was added, and the last
+// node optionally fills whatever space is left.
+type Dock[S ng.Scalar] struct {
+	bounds *Rect[S]
+	gap    S
+	fill   bool
+	items  []dockItem[S]
+	rest   *Rect[S]
+}
+
+type dockItem[S ng.Scalar] struct {
+	node Node[S]
+	side Side
+	size S
+}
+
+// NewDock creates a Dock within bounds with gap spacing between nodes.
+// If fill is true, the last node fills the remaining space.
+func NewDock[S ng.Scalar](bounds *Rect[S], gap S, fill bool) *Dock[S] {
+	return &Dock[S]{
+		bounds: bounds.Clone(),
+		gap:    gap,
+		fill:   fill,
+		rest:   bounds.Clone(),
+	}
+}
+
+// Attach docks n to the given side with the desired size, which is its width
+// for Left and Right and its height for Top and Bottom. A [*Rect] is resized
+// to its space; other nodes are nested at the top-left corner of it.
+func (d *Dock[S]) Attach(n Node[S], side Side, size S) *Dock[S] {
+	d.items = append(d.items, dockItem[S]{n, side, size})
+	d.layout()
+	return d
+}
+
+// Resize changes the bounds of d and arranges the nodes again.
+func (d *Dock[S]) Resize(bounds *Rect[S]) *Dock[S] {
+	d.bounds = bounds.Clone()
+	d.layout()
+	return d
+}
+
+// Rest returns the space not taken by the docked nodes. If d fills, it is the
+// space of the last node.
+func (d *Dock[S]) Rest() *Rect[S] {
+	return d.rest.Clone()
+}
+
+// Nodes returns the docked nodes in the order they were added.
+func (d *Dock[S]) Nodes() Slice[S] {
+	s := make(Slice[S], len(d.items))
+	for i, it := range d.items {
+		s[i] = it.node
+	}
+	return s
+}
+
+func (d *Dock[S]) layout() {
+	rest := d.bounds.Clone()
+	for i, it := range d.items {
+		if d.fill && i == len(d.items)-1 {
+			place(it.node, rest)
+			break
+		}
+		place(it.node, rest.Take(it.side, it.size, d.gap))
+	}
+	d.rest = rest
+}
+
+// Bounds implements [Node] interface.
+func (d *Dock[S]) Bounds() *Rect[S] {
+	return d.bounds
+}
+
+// Shift implements [Node] interface.
+func (d *Dock[S]) Shift(p Point[S]) {
+	d.bounds.Shift(p)
+	d.rest.Shift(p)
+	for _, it := range d.items {
+		it.node.Shift(p)
+	}
+}
+
+// Add translates d by p and returns d.
+func (d *Dock[S]) Add(p Point[S]) *Dock[S] {
+	d.Shift(p)
+	return d
+}
+
+// Align positions the dock relative to the target using anchor points.
+// (ax, ay) is the anchor point on the dock (0-1), and (tax, tay) is the anchor point on the target.
+func (d *Dock[S]) Align(ax, ay float64, target Node[S], tax, tay float64) *Dock[S] {
+	tb := target.Bounds()
+	d.Shift(tb.Anchor(tax, tay).Sub(d.bounds.Anchor(ax, ay)))
+	return d
+}
+
+// Nest positions the dock within the target at the given relative position.
+func (d *Dock[S]) Nest(target Node[S], ax, ay float64) *Dock[S] {
+	return d.Align(ax, ay, target, ax, ay)
+}
+
+// CenterOf centers the dock within the target.
+func (d *Dock[S]) CenterOf(target Node[S]) *Dock[S] {
+	return d.Align(0.5, 0.5, target, 0.5, 0.5)
+}
+
+// StackX stacks the dock horizontally relative to the target.
+func (d *Dock[S]) StackX(target Node[S], tax, tay float64) *Dock[S] {
+	return d.Align(1-tax, tay, target, tax, tay)
+}
+
+// StackY stacks the dock vertically relative to the target.
+func (d *Dock[S]) StackY(target Node[S], tax, tay float64) *Dock[S] {
+	return d.Align(tax, 1-tay, target, tax, tay)
+}
+
+// Clamp constrains the dock within the target bounds while keeping its size.
+func (d *Dock[S]) Clamp(target Node[S]) *Dock[S] {
+	oldPos := d.bounds.Min
+	b := d.bounds.Clone().Clamp(target.Bounds())
+	d.Shift(b.Min.Sub(oldPos))
+	return d
+}
+
+// Fit returns the largest rectangle with the aspect ratio of the bounds that
+// fits within target, positioned at (ax, ay).
+func (d *Dock[S]) Fit(target Node[S], ax, ay float64) *Rect[S] {
+	return d.bounds.Clone().Fit(target, ax, ay)
+}
+
+// Cover returns the smallest rectangle with the aspect ratio of the bounds
+// that covers target, positioned at (ax, ay).
+func (d *Dock[S]) Cover(target Node[S], ax, ay float64) *Rect[S] {
+	return d.bounds.Clone().Cover(target, ax, ay)
+}
+
+// Inset returns the bounds inset by n.
+func (d *Dock[S]) Inset(n S) *Rect[S] {
+	return d.bounds.Inset(n)
+}
+
+// InsetXY returns the bounds inset by x horizontally and y vertically.
+func (d *Dock[S]) InsetXY(x, y S) *Rect[S] {
+	return d.bounds.InsetXY(x, y)
+}
+
+// InsetLTRB returns the bounds inset by the given amounts on each side.
+func (d *Dock[S]) InsetLTRB(left, top, right, bottom S) *Rect[S] {
+	return d.bounds.InsetLTRB(left, top, right, bottom)
+}
+
+// Outset returns the bounds expanded by n.
+func (d *Dock[S]) Outset(n S) *Rect[S] {
+	return d.bounds.Outset(n)
+}
+
+// OutsetXY returns the bounds expanded by x horizontally and y vertically.
+func (d *Dock[S]) OutsetXY(x, y S) *Rect[S] {
+	return d.bounds.OutsetXY(x, y)
+}
+
+// OutsetLTRB returns the bounds expanded by the given amounts on each side.
+func (d *Dock[S]) OutsetLTRB(left, top, right, bottom S) *Rect[S] {
+	return d.bounds.OutsetLTRB(left, top, right, bottom)
+}
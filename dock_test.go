@@ -0,0 +1,38 @@
+package align
+
+import "testing"
+
+func TestDock(t *testing.T) {
+	toolbar, sidebar, status, editor := &Rect[int]{}, &Rect[int]{}, &Rect[int]{}, &Rect[int]{}
+	label := Slice[int]{WH(10, 5), XYWH(10, 0, 10, 5)}
+	d := NewDock(WH(100, 100), 2, true).
+		Attach(toolbar, Top, 10).
+		Attach(sidebar, Left, 20).
+		Attach(status, Bottom, 8).
+		Attach(label, Right, 30).
+		Attach(editor, Left, 0)
+
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "toolbar", got: toolbar, want: XYWH(0, 0, 100, 10)},
+		{name: "sidebar", got: sidebar, want: XYWH(0, 12, 20, 88)},
+		{name: "status", got: status, want: XYWH(22, 92, 78, 8)},
+		{name: "label", got: label.Bounds(), want: XYWH(70, 12, 20, 5)},
+		{name: "editor", got: editor, want: XYWH(22, 12, 46, 78)},
+		{name: "rest", got: d.Rest(), want: XYWH(22, 12, 46, 78)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	d.Nest(WH(200, 200), 1, 1)
+	if want := XYWH(122, 112, 46, 78); !editor.Eq(want) {
+		t.Errorf("after Nest, editor = %v, want %v", editor, want)
+	}
+}
@@ -74,8 +74,8 @@ func inset() error {
 }
 
 func status() error {
-	screen := align.WH(100, 100)      // blue
-	topBar, screen := screen.CutY(10) // cyan
+	screen := align.WH(100, 100)        // blue
+	topBar, screen := screen.CutTop(10) // cyan
 	uiSpace := screen.Inset(10)
 	title := align.WH(30, 10).CenterOf(topBar)       // red
 	portrait := align.WH(20, 30).Nest(uiSpace, 0, 0) // green
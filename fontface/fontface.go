@@ -0,0 +1,50 @@
+// Package fontface adapts [font.Face] to [align.TextMeasurer].
+package fontface
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/eihigh/align"
+)
+
+// Measurer measures text set in a [font.Face].
+type Measurer struct {
+	Face font.Face
+}
+
+var _ align.TextMeasurer = (*Measurer)(nil)
+
+// New returns a Measurer for face.
+func New(face font.Face) *Measurer {
+	return &Measurer{Face: face}
+}
+
+// Advance implements [align.TextMeasurer] interface. It includes kerning.
+func (m *Measurer) Advance(s string) float64 {
+	return float(font.MeasureString(m.Face, s))
+}
+
+// RuneAdvance implements [align.TextMeasurer] interface. Runes missing from
+// the face have the advance of the replacement character.
+func (m *Measurer) RuneAdvance(r rune) float64 {
+	adv, ok := m.Face.GlyphAdvance(r)
+	if !ok {
+		adv, _ = m.Face.GlyphAdvance('�')
+	}
+	return float(adv)
+}
+
+// LineHeight implements [align.TextMeasurer] interface.
+func (m *Measurer) LineHeight() float64 {
+	return float(m.Face.Metrics().Height)
+}
+
+// Ascent implements [align.TextMeasurer] interface.
+func (m *Measurer) Ascent() float64 {
+	return float(m.Face.Metrics().Ascent)
+}
+
+func float(x fixed.Int26_6) float64 {
+	return float64(x) / 64
+}
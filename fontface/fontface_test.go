@@ -0,0 +1,38 @@
+package fontface
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+
+	"github.com/eihigh/align"
+)
+
+func TestMeasurer(t *testing.T) {
+	m := New(basicfont.Face7x13)
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "Advance", got: m.Advance("hello"), want: 35},
+		{name: "RuneAdvance", got: m.RuneAdvance('x'), want: 7},
+		{name: "LineHeight", got: m.LineHeight(), want: 13},
+		{name: "Ascent", got: m.Ascent(), want: 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	p := align.Paragraph[int]{Measurer: m}
+	b := p.Layout("hello world", align.WH(50, 100))
+	if len(b.Lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(b.Lines))
+	}
+	if got, want := b.Lines[1].Line, align.XYXY(0, 13, 35, 26); !got.Eq(want) {
+		t.Errorf("second line = %v, want %v", got, want)
+	}
+}
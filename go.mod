@@ -2,4 +2,7 @@ module github.com/eihigh/align
 
 go 1.24.2
 
-require github.com/eihigh/ng v0.0.1
+require (
+	github.com/eihigh/ng v0.0.1
+	golang.org/x/image v0.30.0
+)
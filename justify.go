@@ -0,0 +1,79 @@
+package align
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// LastRow specifies how [JustifyRows] lays out a last row that is too short
+// to fill the width.
+type LastRow int
+
+const (
+	// LastRowLeft keeps the target height and aligns the row to the left.
+	LastRowLeft LastRow = iota
+	// LastRowCenter keeps the target height and centers the row.
+	LastRowCenter
+	// LastRowJustify stretches the row to fill the width like the others.
+	LastRowJustify
+	// LastRowHide omits the row.
+	LastRowHide
+)
+
+// JustifyRows lays out items with the given aspect ratios (width / height)
+// in rows that exactly fill the width of bounds, like a photo gallery. Each
+// row is scaled to the height closest to rowHeight. It returns a [Slice] of
+// rows, each a [Slice] of item rectangles, stacked from the top of bounds.
+func JustifyRows[S ng.Scalar](bounds *Rect[S], aspects []float64, rowHeight, xGap, yGap S, last LastRow) Slice[S] {
+	width := float64(bounds.Dx())
+	target := float64(rowHeight)
+	// height returns the height of aspects[i:j] justified to the width.
+	height := func(i, j int) float64 {
+		sum := 0.0
+		for _, a := range aspects[i:j] {
+			sum += a
+		}
+		return (width - float64(xGap)*float64(j-i-1)) / sum
+	}
+
+	rows := Slice[S]{}
+	y := bounds.Min.Y
+	for i := 0; i < len(aspects); {
+		j := i + 1
+		for j < len(aspects) && height(i, j) > target {
+			j++
+		}
+		h := height(i, j)
+		short := false // the last row is too short to fill the width
+		if h > target {
+			if last == LastRowHide {
+				break
+			}
+			short = last != LastRowJustify
+			if short {
+				h = target
+			}
+		} else if j-i > 1 && math.Abs(height(i, j-1)-target) < math.Abs(h-target) {
+			j--
+			h = height(i, j)
+		}
+
+		r := XYWH(bounds.Min.X, y, bounds.Dx(), roundTo[S](h, RoundHalfEven))
+		sizes := make([]Size, j-i)
+		for k, a := range aspects[i:j] {
+			sizes[k] = Fr(a)
+			if short {
+				sizes[k] = Fixed(a * h)
+			}
+		}
+		row := r.SplitXSizes(sizes, xGap)
+		if short && last == LastRowCenter {
+			row.Nest(r, .5, 0)
+		}
+		rows = append(rows, row)
+		y = r.Max.Y + yGap
+		i = j
+	}
+	return rows
+}
@@ -0,0 +1,36 @@
+package align
+
+import "testing"
+
+func TestJustifyRows(t *testing.T) {
+	aspects := []float64{1, 2, 1, 1.5, .5, 1}
+	bounds := WH(100, 500)
+	tests := []struct {
+		name string
+		last LastRow
+		rows int
+		tail *Rect[int] // bounds of the last row
+	}{
+		// The first row holds 4 items at 17.09 high, closer to 20 than the
+		// 3 items at 24; the rest is too short to fill the width.
+		{name: "Left", last: LastRowLeft, rows: 2, tail: XYWH(0, 19, 32, 20)},
+		{name: "Center", last: LastRowCenter, rows: 2, tail: XYWH(34, 19, 32, 20)},
+		{name: "Justify", last: LastRowJustify, rows: 2, tail: XYWH(0, 19, 100, 65)},
+		{name: "Hide", last: LastRowHide, rows: 1, tail: XYWH(0, 0, 100, 17)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := JustifyRows(bounds, aspects, 20, 2, 2, tt.last)
+			if len(rows) != tt.rows {
+				t.Fatalf("got %d rows, want %d", len(rows), tt.rows)
+			}
+			if b := rows[0].Bounds(); b.Min.X != 0 || b.Max.X != 100 {
+				t.Errorf("first row = %v does not fill the width", b)
+			}
+			if got := rows.Last().Bounds(); !got.Eq(tt.tail) {
+				t.Errorf("last row = %v, want %v", got, tt.tail)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package align
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eihigh/ng"
+)
+
+// A Length is a distance in one of several units, given by [Px], [Percent],
+// [Em], [Vw], [Vh] or [Dp], and resolved to a scalar by [Units].
+type Length struct {
+	unit   unit
+	value  float64
+	lo, hi *Length
+}
+
+type unit int
+
+const (
+	unitPx unit = iota
+	unitPercent
+	unitEm
+	unitVw
+	unitVh
+	unitDp
+)
+
+var unitSuffixes = [...]string{
+	unitPx:      "px",
+	unitPercent: "%",
+	unitEm:      "em",
+	unitVw:      "vw",
+	unitVh:      "vh",
+	unitDp:      "dp",
+}
+
+// Px returns a Length of n pixels, the units of S.
+func Px(n float64) Length {
+	return Length{unit: unitPx, value: n}
+}
+
+// Percent returns a Length of n percent of the parent's width or height,
+// along the axis the length is used on.
+func Percent(n float64) Length {
+	return Length{unit: unitPercent, value: n}
+}
+
+// Em returns a Length of n times the font size.
+func Em(n float64) Length {
+	return Length{unit: unitEm, value: n}
+}
+
+// Vw returns a Length of n percent of the viewport width.
+func Vw(n float64) Length {
+	return Length{unit: unitVw, value: n}
+}
+
+// Vh returns a Length of n percent of the viewport height.
+func Vh(n float64) Length {
+	return Length{unit: unitVh, value: n}
+}
+
+// Dp returns a Length of n device-independent pixels, scaled by the device
+// pixel ratio.
+func Dp(n float64) Length {
+	return Length{unit: unitDp, value: n}
+}
+
+// ParseLength parses a length such as "12px", "2%", "1.5em", "10vw", "5vh"
+// or "8dp". A number without a unit is in pixels.
+func ParseLength(s string) (Length, error) {
+	num := strings.TrimSpace(s)
+	u := unitPx
+	for i, suffix := range unitSuffixes {
+		if strings.HasSuffix(num, suffix) {
+			u = unit(i)
+			num = strings.TrimSuffix(num, suffix)
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return Length{}, fmt.Errorf("align: invalid length %q", s)
+	}
+	return Length{unit: u, value: n}, nil
+}
+
+// AtLeast returns l limited to be at least lo, as in "2% but at least 8dp".
+func (l Length) AtLeast(lo Length) Length {
+	l.lo = &lo
+	return l
+}
+
+// AtMost returns l limited to be at most hi.
+func (l Length) AtMost(hi Length) Length {
+	l.hi = &hi
+	return l
+}
+
+// String returns l in the syntax of [ParseLength], ignoring its limits.
+func (l Length) String() string {
+	return strconv.FormatFloat(l.value, 'g', -1, 64) + unitSuffixes[l.unit]
+}
+
+// Units resolves [Length] values to scalars. Its methods that take a
+// rectangle r resolve Percent lengths relative to r if Parent is nil.
+type Units[S ng.Scalar] struct {
+	// Parent is the reference of Percent lengths.
+	Parent *Rect[S]
+	// Viewport is the reference of Vw and Vh lengths.
+	Viewport *Rect[S]
+	// FontSize is the size of Em in pixels.
+	FontSize float64
+	// Scale is the number of pixels per Dp. Zero means 1.
+	Scale float64
+	// Rounding converts the resolved lengths to integer scalars.
+	Rounding Rounding
+}
+
+// resolve returns l in pixels. base is the parent's size along the axis.
+func (u Units[S]) resolve(l Length, base float64) float64 {
+	var v float64
+	switch l.unit {
+	case unitPercent:
+		v = l.value / 100 * base
+	case unitEm:
+		v = l.value * u.FontSize
+	case unitVw:
+		if u.Viewport != nil {
+			v = l.value / 100 * float64(u.Viewport.Dx())
+		}
+	case unitVh:
+		if u.Viewport != nil {
+			v = l.value / 100 * float64(u.Viewport.Dy())
+		}
+	case unitDp:
+		v = l.value
+		if u.Scale != 0 {
+			v *= u.Scale
+		}
+	default:
+		v = l.value
+	}
+	if l.lo != nil {
+		v = max(v, u.resolve(*l.lo, base))
+	}
+	if l.hi != nil {
+		v = min(v, u.resolve(*l.hi, base))
+	}
+	return v
+}
+
+// X resolves l as a horizontal length; Percent is relative to the parent's
+// width.
+func (u Units[S]) X(l Length) S {
+	base := 0.0
+	if u.Parent != nil {
+		base = float64(u.Parent.Dx())
+	}
+	return roundTo[S](u.resolve(l, base), u.Rounding)
+}
+
+// Y resolves l as a vertical length; Percent is relative to the parent's
+// height.
+func (u Units[S]) Y(l Length) S {
+	base := 0.0
+	if u.Parent != nil {
+		base = float64(u.Parent.Dy())
+	}
+	return roundTo[S](u.resolve(l, base), u.Rounding)
+}
+
+// of returns u with r as the parent if u has none.
+func (u Units[S]) of(r *Rect[S]) Units[S] {
+	if u.Parent == nil {
+		u.Parent = r
+	}
+	return u
+}
+
+// WH returns a rectangle of width w and height h at the origin, like [WH].
+func (u Units[S]) WH(w, h Length) *Rect[S] {
+	return WH(u.X(w), u.Y(h))
+}
+
+// XYWH returns a rectangle like [XYWH], relative to the top-left corner of
+// the parent if any.
+func (u Units[S]) XYWH(x, y, w, h Length) *Rect[S] {
+	r := XYWH(u.X(x), u.Y(y), u.X(w), u.Y(h))
+	if u.Parent != nil {
+		r.Add(u.Parent.Min)
+	}
+	return r
+}
+
+// Inset returns r inset by n on all sides, like [Rect.Inset].
+func (u Units[S]) Inset(r *Rect[S], n Length) *Rect[S] {
+	return u.InsetLTRB(r, n, n, n, n)
+}
+
+// InsetXY returns r inset by x horizontally and y vertically, like
+// [Rect.InsetXY].
+func (u Units[S]) InsetXY(r *Rect[S], x, y Length) *Rect[S] {
+	return u.InsetLTRB(r, x, y, x, y)
+}
+
+// InsetLTRB returns r inset by the given lengths on each side, like
+// [Rect.InsetLTRB].
+func (u Units[S]) InsetLTRB(r *Rect[S], left, top, right, bottom Length) *Rect[S] {
+	u = u.of(r)
+	return r.InsetLTRB(u.X(left), u.Y(top), u.X(right), u.Y(bottom))
+}
+
+// Outset returns r expanded by n on all sides, like [Rect.Outset].
+func (u Units[S]) Outset(r *Rect[S], n Length) *Rect[S] {
+	u = u.of(r)
+	return r.OutsetLTRB(u.X(n), u.Y(n), u.X(n), u.Y(n))
+}
+
+// CutX divides r at the width w, like [Rect.CutX].
+func (u Units[S]) CutX(r *Rect[S], w Length) (left, right *Rect[S]) {
+	return r.CutX(u.of(r).X(w))
+}
+
+// CutY divides r at the height h, like [Rect.CutY].
+func (u Units[S]) CutY(r *Rect[S], h Length) (top, bottom *Rect[S]) {
+	return r.CutY(u.of(r).Y(h))
+}
+
+// Cut divides r into the rectangle of size n along side and the rest, like
+// [Rect.Cut].
+func (u Units[S]) Cut(r *Rect[S], side Side, n Length) (cut, rest *Rect[S]) {
+	u = u.of(r)
+	if side == Left || side == Right {
+		return r.Cut(side, u.X(n))
+	}
+	return r.Cut(side, u.Y(n))
+}
+
+// Take cuts a strip of size n and a gap from side of r, like [Rect.Take].
+func (u Units[S]) Take(r *Rect[S], side Side, n, gap Length) *Rect[S] {
+	u = u.of(r)
+	if side == Left || side == Right {
+		return r.Take(side, u.X(n), u.X(gap))
+	}
+	return r.Take(side, u.Y(n), u.Y(gap))
+}
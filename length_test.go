@@ -0,0 +1,79 @@
+package align
+
+import "testing"
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"12px", "12px"},
+		{"12", "12px"},
+		{" 2.5% ", "2.5%"},
+		{"1.5em", "1.5em"},
+		{"10vw", "10vw"},
+		{"-5vh", "-5vh"},
+		{"8dp", "8dp"},
+	}
+	for _, tt := range tests {
+		l, err := ParseLength(tt.in)
+		if err != nil {
+			t.Errorf("ParseLength(%q): %v", tt.in, err)
+			continue
+		}
+		if got := l.String(); got != tt.want {
+			t.Errorf("ParseLength(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []string{"", "px", "12pt", "1.2.3em"} {
+		if _, err := ParseLength(in); err == nil {
+			t.Errorf("ParseLength(%q) succeeded", in)
+		}
+	}
+}
+
+func TestUnits(t *testing.T) {
+	screen := WH(1000, 500)
+	u := Units[int]{Parent: WH(400, 200), Viewport: screen, FontSize: 16, Scale: 2}
+	tests := []struct {
+		name      string
+		got, want int
+	}{
+		{name: "px", got: u.X(Px(12)), want: 12},
+		{name: "percent x", got: u.X(Percent(10)), want: 40},
+		{name: "percent y", got: u.Y(Percent(10)), want: 20},
+		{name: "em", got: u.X(Em(1.5)), want: 24},
+		{name: "vw", got: u.Y(Vw(10)), want: 100},
+		{name: "vh", got: u.X(Vh(10)), want: 50},
+		{name: "dp", got: u.X(Dp(8)), want: 16},
+		{name: "at least", got: u.X(Percent(2).AtLeast(Dp(8))), want: 16},
+		{name: "at least unused", got: u.X(Percent(10).AtLeast(Dp(8))), want: 40},
+		{name: "at most", got: u.X(Percent(50).AtMost(Em(10))), want: 160},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+
+	free := Units[int]{Viewport: screen, FontSize: 16}
+	card := XYWH(100, 100, 200, 100)
+	left, _ := free.CutX(card, Percent(25))
+	rects := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "InsetLTRB", got: free.InsetLTRB(card, Percent(10), Percent(10), Em(1), Px(0)), want: XYXY(120, 110, 284, 200)},
+		{name: "CutX", got: left, want: XYWH(100, 100, 50, 100)},
+		{name: "WH", got: u.WH(Percent(50), Em(2)), want: WH(200, 32)},
+		{name: "XYWH", got: Units[int]{Parent: card}.XYWH(Percent(50), Px(0), Percent(50), Percent(100)), want: XYXY(200, 100, 300, 200)},
+	}
+	for _, tt := range rects {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
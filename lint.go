@@ -0,0 +1,273 @@
+package align
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/eihigh/ng"
+)
+
+// LintKind is the kind of problem a [Finding] reports.
+type LintKind int
+
+const (
+	// LintOverlap reports two sibling nodes that overlap.
+	LintOverlap LintKind = iota
+	// LintOutOfBounds reports a node that is not within the bounds of its
+	// parent, or of the root bounds.
+	LintOutOfBounds
+	// LintEmpty reports a leaf with zero width or height.
+	LintEmpty
+	// LintIllFormed reports a rectangle whose minimum exceeds its maximum,
+	// which [Rect.Canon] would fix.
+	LintIllFormed
+	// LintSubpixel reports float coordinates that are not on the integer
+	// pixel grid.
+	LintSubpixel
+)
+
+func (k LintKind) String() string {
+	switch k {
+	case LintOverlap:
+		return "overlap"
+	case LintOutOfBounds:
+		return "out of bounds"
+	case LintEmpty:
+		return "empty"
+	case LintIllFormed:
+		return "ill-formed"
+	case LintSubpixel:
+		return "sub-pixel"
+	}
+	return fmt.Sprintf("LintKind(%d)", int(k))
+}
+
+// A Finding is a problem found by [Lint] in a node.
+type Finding[S ng.Scalar] struct {
+	Kind LintKind
+	// Path locates the node from the root, such as "root[2].title".
+	Path string
+	// Rect is the bounds of the node.
+	Rect *Rect[S]
+	// Other is the path of the overlapped node for LintOverlap.
+	Other string
+	// Limit is the bounds the node escapes for LintOutOfBounds.
+	Limit *Rect[S]
+}
+
+func (f Finding[S]) String() string {
+	switch f.Kind {
+	case LintOverlap:
+		return fmt.Sprintf("%s %v overlaps %s", f.Path, f.Rect, f.Other)
+	case LintOutOfBounds:
+		return fmt.Sprintf("%s %v is out of bounds %v", f.Path, f.Rect, f.Limit)
+	case LintEmpty:
+		return fmt.Sprintf("%s %v is empty", f.Path, f.Rect)
+	case LintIllFormed:
+		return fmt.Sprintf("%s %v is ill-formed", f.Path, f.Rect)
+	case LintSubpixel:
+		return fmt.Sprintf("%s %v is not pixel-aligned", f.Path, f.Rect)
+	}
+	return fmt.Sprintf("%s %v: %v", f.Path, f.Rect, f.Kind)
+}
+
+// Findings is the result of [Lint].
+type Findings[S ng.Scalar] []Finding[S]
+
+// Err returns an error listing the findings, or nil if there are none, for
+// use in tests:
+//
+//	if err := align.Lint(ui, screen).Err(); err != nil {
+//		t.Error(err)
+//	}
+func (fs Findings[S]) Err() error {
+	if len(fs) == 0 {
+		return nil
+	}
+	lines := make([]string, len(fs))
+	for i, f := range fs {
+		lines[i] = f.String()
+	}
+	return errors.New("align: lint:\n\t" + strings.Join(lines, "\n\t"))
+}
+
+// Without returns the findings that are not of the given kinds, such as
+// LintOverlap for layouts that stack nodes on purpose.
+func (fs Findings[S]) Without(kinds ...LintKind) Findings[S] {
+	var out Findings[S]
+	for _, f := range fs {
+		if !slices.Contains(kinds, f.Kind) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Lint checks the node tree root for common layout mistakes: overlapping
+// siblings, nodes escaping their parent or bounds, empty or ill-formed
+// rectangles and, for float scalars, coordinates off the pixel grid. bounds
+// may be nil to skip the bounds check of the root.
+//
+// Lint descends into [Slice], [Map], [*Dock], [*TreemapGroup], [NinePatch],
+// [*TextBlock] and [*Baselined] nodes. The children of a [Slice] or [Map]
+// are checked against the bounds of the nearest other ancestor, since the
+// bounds of those containers always contain their children. For the same
+// reason, sibling [Slice] and [Map] nodes overlap only if their descendants do.
+// Nil children are skipped.
+func Lint[S ng.Scalar](root Node[S], bounds *Rect[S]) Findings[S] {
+	var fs Findings[S]
+	lint(&fs, root, "root", bounds)
+	return fs
+}
+
+// lintChild is a child node with its path.
+type lintChild[S ng.Scalar] struct {
+	node Node[S]
+	path string
+}
+
+// children returns the children of n with their paths, and whether n is a
+// container.
+func children[S ng.Scalar](n Node[S], path string) ([]lintChild[S], bool) {
+	indexed := func(s Slice[S]) []lintChild[S] {
+		cs := make([]lintChild[S], len(s))
+		for i, c := range s {
+			cs[i] = lintChild[S]{c, fmt.Sprintf("%s[%d]", path, i)}
+		}
+		return cs
+	}
+	switch n := n.(type) {
+	case Slice[S]:
+		return indexed(n), true
+	case Map[S]:
+		cs := make([]lintChild[S], 0, len(n))
+		for _, k := range slices.Sorted(maps.Keys(n)) {
+			cs = append(cs, lintChild[S]{n[k], path + "." + k})
+		}
+		return cs, true
+	case *Dock[S]:
+		return indexed(n.Nodes()), true
+	case *TreemapGroup[S]:
+		return indexed(n.Children), true
+	case NinePatch[S]:
+		s := make(Slice[S], len(n))
+		for i, r := range n {
+			s[i] = r
+		}
+		return indexed(s), true
+	case *TextBlock[S]:
+		s := make(Slice[S], len(n.Lines))
+		for i, l := range n.Lines {
+			s[i] = l
+		}
+		return indexed(s), true
+	case *Baselined[S]:
+		return children(n.Node, path)
+	}
+	return nil, false
+}
+
+func lint[S ng.Scalar](fs *Findings[S], n Node[S], path string, limit *Rect[S]) {
+	if n == nil {
+		return
+	}
+	cs, container := children(n, path)
+	// The bounds of a Slice or Map are the union of its children, which are
+	// checked instead. They are not computed, as they would panic on a nil
+	// child.
+	union := false
+	switch n.(type) {
+	case Slice[S], Map[S]:
+		union = true
+	}
+	var r *Rect[S]
+	if !union {
+		r = n.Bounds()
+	}
+
+	if limit != nil && !union && !r.In(limit) {
+		*fs = append(*fs, Finding[S]{Kind: LintOutOfBounds, Path: path, Rect: r.Clone(), Limit: limit.Clone()})
+	}
+	if !container {
+		switch {
+		case *r != *r.Clone().Canon():
+			*fs = append(*fs, Finding[S]{Kind: LintIllFormed, Path: path, Rect: r.Clone()})
+		case r.Empty():
+			*fs = append(*fs, Finding[S]{Kind: LintEmpty, Path: path, Rect: r.Clone()})
+		}
+		if !onGrid(r) {
+			*fs = append(*fs, Finding[S]{Kind: LintSubpixel, Path: path, Rect: r.Clone()})
+		}
+		return
+	}
+
+	// Siblings that are a Slice or Map are compared by their descendants,
+	// since their bounds cover the gaps between them.
+	ls := make([][]*Rect[S], len(cs))
+	for i, c := range cs {
+		ls[i] = leaves(nil, c.node)
+	}
+	for i, a := range cs {
+		for j, b := range cs[i+1:] {
+			if r := overlapping(ls[i], ls[i+1+j]); r != nil {
+				*fs = append(*fs, Finding[S]{Kind: LintOverlap, Path: a.path, Rect: r.Clone(), Other: b.path})
+			}
+		}
+	}
+	childLimit := limit
+	if !union {
+		childLimit = r
+	}
+	for _, c := range cs {
+		lint(fs, c.node, c.path, childLimit)
+	}
+}
+
+// leaves appends to rs the bounds of n, or of the descendants of n that are
+// not a Slice or Map if n is one, and returns the extended slice.
+func leaves[S ng.Scalar](rs []*Rect[S], n Node[S]) []*Rect[S] {
+	switch n := n.(type) {
+	case nil:
+	case Slice[S]:
+		for _, c := range n {
+			rs = leaves(rs, c)
+		}
+	case Map[S]:
+		for _, k := range slices.Sorted(maps.Keys(n)) {
+			rs = leaves(rs, n[k])
+		}
+	default:
+		rs = append(rs, n.Bounds())
+	}
+	return rs
+}
+
+// overlapping returns the first rectangle of as that overlaps one of bs, or
+// nil.
+func overlapping[S ng.Scalar](as, bs []*Rect[S]) *Rect[S] {
+	for _, a := range as {
+		for _, b := range bs {
+			if a.Overlaps(b) {
+				return a
+			}
+		}
+	}
+	return nil
+}
+
+// onGrid reports whether the coordinates of r are integers.
+func onGrid[S ng.Scalar](r *Rect[S]) bool {
+	if isInt[S]() {
+		return true
+	}
+	for _, v := range [...]S{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y} {
+		if f := float64(v); f != math.Trunc(f) {
+			return false
+		}
+	}
+	return true
+}
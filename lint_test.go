@@ -0,0 +1,96 @@
+package align
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	screen := WH(100, 100)
+	ui := Map[int]{
+		"header": WH(100, 20),
+		"body": Slice[int]{
+			XYWH(0, 20, 50, 50),
+			XYWH(40, 20, 50, 50), // overlaps [0]
+			XYWH(0, 70, 0, 10),   // empty
+		},
+		"footer": XYWH(0, 90, 120, 10), // out of bounds
+		"broken": &Rect[int]{XY(10, 10), XY(5, 5)},
+		"groups": Slice[int]{ // boxes overlap, leaves do not
+			Slice[int]{XYWH(50, 70, 10, 10), XYWH(70, 80, 10, 10)},
+			Slice[int]{XYWH(70, 70, 10, 10)},
+		},
+	}
+	type finding struct {
+		kind LintKind
+		path string
+	}
+	var got []finding
+	for _, f := range Lint[int](ui, screen) {
+		got = append(got, finding{f.Kind, f.Path})
+		if f.Kind == LintOutOfBounds && !f.Limit.Eq(screen) {
+			t.Errorf("%v: Limit = %v, want %v", f, f.Limit, screen)
+		}
+		if f.Kind == LintOverlap && f.Other != "root.body[1]" {
+			t.Errorf("%v: Other = %q, want %q", f, f.Other, "root.body[1]")
+		}
+	}
+	want := []finding{
+		{LintOverlap, "root.body[0]"},
+		{LintOutOfBounds, "root.footer"},
+		{LintIllFormed, "root.broken"},
+		{LintEmpty, "root.body[2]"},
+	}
+	for _, w := range want {
+		if !slices.Contains(got, w) {
+			t.Errorf("missing finding %v in %v", w, got)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d findings, want %d: %v", len(got), len(want), got)
+	}
+}
+
+func TestLintNested(t *testing.T) {
+	d := NewDock(WH(100, 100), 0, true)
+	d.Attach(WH(0, 20), Top, 20)
+	side := Slice[int]{XYWH(0, 0, 10, 10)}
+	d.Attach(side, Left, 30) // moved below the top bar, clear of it
+
+	fs := Lint[int](d, nil)
+	if len(fs) != 0 {
+		t.Errorf("unexpected findings: %v", fs.Err())
+	}
+	if got, want := side[0].Bounds(), XYWH(0, 20, 10, 10); !got.Eq(want) {
+		t.Errorf("docked slice = %v, want %v", got, want)
+	}
+
+	holes := Slice[int]{nil, Map[int]{"a": nil, "b": WH(10, 10)}, XYWH(10, 0, 10, 10)}
+	if fs := Lint[int](holes, WH(20, 10)); len(fs) != 0 {
+		t.Errorf("nil children: unexpected findings: %v", fs.Err())
+	}
+
+	sub := Slice[float64]{XYWH(0.5, 0, 10, 10), XYWH(20.0, 0, 10, 10)}
+	fs2 := Lint[float64](sub, WH(100.0, 100))
+	if len(fs2) != 1 || fs2[0].Kind != LintSubpixel || fs2[0].Path != "root[0]" {
+		t.Errorf("got %v, want one sub-pixel finding at root[0]", fs2)
+	}
+}
+
+func TestFindingsErr(t *testing.T) {
+	clean := Slice[int]{WH(10, 10), XYWH(10, 0, 10, 10)}
+	if err := Lint[int](clean, WH(20, 10)).Err(); err != nil {
+		t.Errorf("clean layout: %v", err)
+	}
+
+	stacked := Slice[int]{WH(10, 10), WH(10, 10)}
+	fs := Lint[int](stacked, nil)
+	err := fs.Err()
+	if err == nil || !strings.Contains(err.Error(), "root[0] (0,0)-(10,10) overlaps root[1]") {
+		t.Errorf("Err() = %v", err)
+	}
+	if err := fs.Without(LintOverlap).Err(); err != nil {
+		t.Errorf("Without(LintOverlap).Err() = %v", err)
+	}
+}
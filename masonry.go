@@ -0,0 +1,100 @@
+package align
+
+import "github.com/eihigh/ng"
+
+// MasonryMode selects the column a [Masonry] places each item in.
+type MasonryMode int
+
+const (
+	// MasonryShortest places each item in the shortest column, preferring
+	// the leftmost one on ties.
+	MasonryShortest MasonryMode = iota
+	// MasonryInOrder places the items in the columns from left to right in
+	// turn.
+	MasonryInOrder
+)
+
+// Masonry arranges items of varying heights into columns, Pinterest-style.
+type Masonry[S ng.Scalar] struct {
+	bounds  *Rect[S]
+	cols    Slice[S]
+	bottoms []S // next y of each column
+	yGap    S
+	mode    MasonryMode
+	next    int
+	s       Slice[S]
+}
+
+// NewMasonry creates a Masonry with the given number of columns within
+// bounds, with xGap between columns and yGap between items.
+func NewMasonry[S ng.Scalar](bounds *Rect[S], columns int, xGap, yGap S, mode MasonryMode) *Masonry[S] {
+	columns = max(1, columns)
+	m := &Masonry[S]{
+		bounds:  bounds.Clone(),
+		cols:    bounds.Clone().SplitX(columns, xGap),
+		bottoms: make([]S, columns),
+		yGap:    yGap,
+		mode:    mode,
+	}
+	for i := range m.bottoms {
+		m.bottoms[i] = bounds.Min.Y
+	}
+	return m
+}
+
+// NewMasonryMinWidth is like [NewMasonry] but uses as many columns as fit
+// within bounds with at least minWidth each.
+func NewMasonryMinWidth[S ng.Scalar](bounds *Rect[S], minWidth, xGap, yGap S, mode MasonryMode) *Masonry[S] {
+	n := 1
+	if minWidth+xGap > 0 {
+		n = int((bounds.Dx() + xGap) / (minWidth + xGap))
+	}
+	return NewMasonry(bounds, n, xGap, yGap, mode)
+}
+
+// Columns returns the number of columns.
+func (m *Masonry[S]) Columns() int {
+	return len(m.cols)
+}
+
+// Column returns the rectangle of the i-th column.
+func (m *Masonry[S]) Column(i int) *Rect[S] {
+	return m.cols[i].Bounds().Clone()
+}
+
+// Add places r in a column, resizing it to the column width while keeping
+// its height. It returns the index of the column.
+func (m *Masonry[S]) Add(r *Rect[S]) int {
+	i := 0
+	switch m.mode {
+	case MasonryInOrder:
+		i = m.next
+		m.next = (m.next + 1) % len(m.cols)
+	default:
+		for j, b := range m.bottoms {
+			if b < m.bottoms[i] {
+				i = j
+			}
+		}
+	}
+	col := m.cols[i].Bounds()
+	h := r.Dy()
+	r.Min = Point[S]{col.Min.X, m.bottoms[i]}
+	r.Max = Point[S]{col.Max.X, m.bottoms[i] + h}
+	m.bottoms[i] = r.Max.Y + m.yGap
+	m.s = append(m.s, r)
+	return i
+}
+
+// Height returns the height of the content from the top of the bounds to the
+// bottom of the lowest item, for scrolling.
+func (m *Masonry[S]) Height() S {
+	bottom := m.bounds.Min.Y
+	for _, r := range m.s {
+		bottom = max(bottom, r.Bounds().Max.Y)
+	}
+	return bottom - m.bounds.Min.Y
+}
+
+// Slice returns all rectangles added to the masonry.
+func (m *Masonry[S]) Slice() Slice[S] { return m.s }
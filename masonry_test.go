@@ -0,0 +1,57 @@
+package align
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMasonry(t *testing.T) {
+	heights := []int{30, 10, 20, 10, 10}
+	tests := []struct {
+		name   string
+		m      *Masonry[int]
+		want   Slice[int]
+		height int
+	}{
+		{
+			name: "Shortest",
+			m:    NewMasonry(WH(64, 200), 3, 2, 4, MasonryShortest),
+			want: Slice[int]{
+				XYWH(0, 0, 20, 30),
+				XYWH(22, 0, 20, 10),
+				XYWH(44, 0, 20, 20),
+				XYWH(22, 14, 20, 10),
+				XYWH(44, 24, 20, 10),
+			},
+			height: 34,
+		},
+		{
+			name: "InOrder",
+			m:    NewMasonryMinWidth(WH(64, 200), 25, 2, 4, MasonryInOrder),
+			want: Slice[int]{
+				XYWH(0, 0, 31, 30),
+				XYWH(33, 0, 31, 10),
+				XYWH(0, 34, 31, 20),
+				XYWH(33, 14, 31, 10),
+				XYWH(0, 58, 31, 10),
+			},
+			height: 68,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, h := range heights {
+				tt.m.Add(WH(1, h))
+			}
+			if !slices.EqualFunc(tt.m.Slice(), tt.want, func(a, b Node[int]) bool {
+				return a.Bounds().Eq(b.Bounds())
+			}) {
+				t.Errorf("got %v, want %v", tt.m.Slice(), tt.want)
+			}
+			if got := tt.m.Height(); got != tt.height {
+				t.Errorf("Height() = %d, want %d", got, tt.height)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package align
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Indices of the parts of a [NinePatch], in row-major order.
+const (
+	NineTopLeft = iota
+	NineTop
+	NineTopRight
+	NineLeft
+	NineCenter
+	NineRight
+	NineBottomLeft
+	NineBottom
+	NineBottomRight
+)
+
+// NinePatch holds the nine parts of a 9-slice rectangle: four corners, four
+// edges and the center, indexed by NineTopLeft through NineBottomRight.
+type NinePatch[S ng.Scalar] [9]*Rect[S]
+
+// NineSlice divides r into nine parts, with corners of width left and right
+// and height top and bottom. The center is r inset as with [Rect.InsetLTRB],
+// so the corners shrink proportionally if r is too small for them.
+func NineSlice[S ng.Scalar](r *Rect[S], left, top, right, bottom S) NinePatch[S] {
+	in := r.InsetLTRB(left, top, right, bottom)
+	xs := [4]S{r.Min.X, in.Min.X, in.Max.X, r.Max.X}
+	ys := [4]S{r.Min.Y, in.Min.Y, in.Max.Y, r.Max.Y}
+	var p NinePatch[S]
+	for y := range 3 {
+		for x := range 3 {
+			p[y*3+x] = XYXY(xs[x], ys[y], xs[x+1], ys[y+1])
+		}
+	}
+	return p
+}
+
+// NineSliceSource divides an image of the given size into nine parts as
+// with [NineSlice], for the source rectangles of a 9-slice sprite.
+func NineSliceSource[S ng.Scalar](size Point[S], left, top, right, bottom S) NinePatch[S] {
+	return NineSlice(PosSize(Point[S]{}, size), left, top, right, bottom)
+}
+
+// Bounds implements [Node] interface.
+func (p NinePatch[S]) Bounds() *Rect[S] {
+	return &Rect[S]{p[NineTopLeft].Min, p[NineBottomRight].Max}
+}
+
+// Shift implements [Node] interface.
+func (p NinePatch[S]) Shift(d Point[S]) {
+	for _, r := range p {
+		r.Shift(d)
+	}
+}
+
+// NineMode specifies how the edges or the center of a [NinePatch] are
+// filled from the source image.
+type NineMode int
+
+const (
+	// NineStretch scales the source part to the destination part.
+	NineStretch NineMode = iota
+	// NineTile repeats the source part at its own size along the edge, or
+	// in both directions for the center. The last tiles are clipped.
+	NineTile
+)
+
+// NinePiece is a source rectangle to be drawn into a destination rectangle.
+type NinePiece[S ng.Scalar] struct {
+	Src, Dst *Rect[S]
+}
+
+// Pieces returns the pieces that draw the 9-slice sprite with the source
+// parts src into p. The corners are always stretched, which keeps them at
+// their size when p and src have the same corner sizes.
+func (p NinePatch[S]) Pieces(src NinePatch[S], edges, center NineMode) []NinePiece[S] {
+	var pieces []NinePiece[S]
+	for i := range p {
+		mode := edges
+		tileX, tileY := false, false
+		switch i {
+		case NineTopLeft, NineTopRight, NineBottomLeft, NineBottomRight:
+			mode = NineStretch
+		case NineTop, NineBottom:
+			tileX = true
+		case NineLeft, NineRight:
+			tileY = true
+		case NineCenter:
+			mode = center
+			tileX, tileY = true, true
+		}
+		if mode == NineStretch {
+			pieces = append(pieces, NinePiece[S]{Src: src[i].Clone(), Dst: p[i].Clone()})
+			continue
+		}
+		pieces = append(pieces, tilePieces(src[i], p[i], tileX, tileY)...)
+	}
+	return pieces
+}
+
+// tilePieces fills dst with copies of src, repeated horizontally if tileX and
+// vertically if tileY, and stretched in the other direction.
+func tilePieces[S ng.Scalar](src, dst *Rect[S], tileX, tileY bool) []NinePiece[S] {
+	w, h := dst.Dx(), dst.Dy()
+	nx, ny := 1, 1
+	if tileX && src.Dx() > 0 {
+		w = src.Dx()
+		nx = int(math.Ceil(float64(dst.Dx()) / float64(w)))
+	}
+	if tileY && src.Dy() > 0 {
+		h = src.Dy()
+		ny = int(math.Ceil(float64(dst.Dy()) / float64(h)))
+	}
+	var pieces []NinePiece[S]
+	for _, n := range XYWH(dst.Min.X, dst.Min.Y, w, h).Repeat(nx, ny, 0, 0) {
+		d, s := n.Bounds(), src.Clone()
+		if over := d.Max.X - dst.Max.X; over > 0 {
+			d.Max.X -= over
+			s.Max.X -= over
+		}
+		if over := d.Max.Y - dst.Max.Y; over > 0 {
+			d.Max.Y -= over
+			s.Max.Y -= over
+		}
+		if !d.Empty() {
+			pieces = append(pieces, NinePiece[S]{Src: s, Dst: d})
+		}
+	}
+	return pieces
+}
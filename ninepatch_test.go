@@ -0,0 +1,60 @@
+package align
+
+import "testing"
+
+func TestNineSlice(t *testing.T) {
+	p := NineSlice(XYWH(10, 10, 100, 50), 8, 6, 8, 6)
+	small := NineSlice(WH(10, 10), 8, 8, 8, 8)
+	src := NineSliceSource(XY(24, 24), 8, 8, 8, 8)
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "center", got: p[NineCenter], want: XYXY(18, 16, 102, 54)},
+		{name: "top right", got: p[NineTopRight], want: XYXY(102, 10, 110, 16)},
+		{name: "bottom left", got: p[NineBottomLeft], want: XYXY(10, 54, 18, 60)},
+		{name: "bounds", got: p.Bounds(), want: XYWH(10, 10, 100, 50)},
+		{name: "too small", got: small[NineTopLeft], want: XYXY(0, 0, 5, 5)},
+		{name: "source", got: src[NineCenter], want: XYXY(8, 8, 16, 16)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNinePieces(t *testing.T) {
+	src := NineSliceSource(XY(24, 24), 8, 8, 8, 8)
+	dst := NineSlice(WH(28, 24), 8, 8, 8, 8)
+
+	if got := dst.Pieces(src, NineStretch, NineStretch); len(got) != 9 {
+		t.Errorf("stretch: got %d pieces, want 9", len(got))
+	}
+	if got := dst.Pieces(src, NineTile, NineTile); len(got) != 12 {
+		t.Errorf("tile: got %d pieces, want 12", len(got))
+	}
+
+	pieces := dst.Pieces(src, NineTile, NineStretch)
+	if len(pieces) != 11 {
+		t.Fatalf("tile edges: got %d pieces, want 11", len(pieces))
+	}
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "first tile dst", got: pieces[1].Dst, want: XYXY(8, 0, 16, 8)},
+		{name: "first tile src", got: pieces[1].Src, want: XYXY(8, 0, 16, 8)},
+		{name: "clipped tile dst", got: pieces[2].Dst, want: XYXY(16, 0, 20, 8)},
+		{name: "clipped tile src", got: pieces[2].Src, want: XYXY(8, 0, 12, 8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,574 @@
+package align
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// PackAlgorithm selects the bin packing algorithm of a [Packer].
+type PackAlgorithm int
+
+const (
+	// MaxRects tracks all maximal free rectangles. It packs tightest but is
+	// the slowest.
+	MaxRects PackAlgorithm = iota
+	// Skyline tracks the top edge of the packed items. It is fast and packs
+	// well for items of similar height.
+	Skyline
+	// Guillotine splits the free space with straight cuts.
+	Guillotine
+)
+
+// PackHeuristic selects how [MaxRects] chooses a free rectangle for an item.
+type PackHeuristic int
+
+const (
+	// BestShortSideFit minimizes the shorter leftover side.
+	BestShortSideFit PackHeuristic = iota
+	// BestLongSideFit minimizes the longer leftover side.
+	BestLongSideFit
+	// BestAreaFit minimizes the leftover area.
+	BestAreaFit
+	// BottomLeft places items as low and then as far left as possible.
+	BottomLeft
+	// ContactPoint maximizes the edge length touching the bin and other items.
+	ContactPoint
+)
+
+// PackOptions configures a [Packer].
+type PackOptions struct {
+	Algorithm PackAlgorithm
+	// Heuristic is only used by MaxRects.
+	Heuristic PackHeuristic
+	// Rotate allows items to be rotated by 90 degrees.
+	Rotate bool
+	// Padding is the space kept between items.
+	Padding int
+	// PowerOfTwo rounds the bin size up to powers of two.
+	PowerOfTwo bool
+	// MaxWidth and MaxHeight let the bin grow up to the given size when an
+	// item does not fit. Zero values disable growth.
+	MaxWidth, MaxHeight int
+}
+
+// A Placement is an item placed by a [Packer].
+type Placement struct {
+	// Index is the index of the item in the input of [Packer.Pack], or the
+	// number of preceding successful insertions for [Packer.Insert].
+	Index int
+	Rect  *Rect[int]
+	// Rotated reports whether the item was rotated by 90 degrees.
+	Rotated bool
+}
+
+// A PackReport is the result of [Packer.Pack].
+type PackReport struct {
+	Placements []Placement
+	// Failed holds the indices of the items that did not fit.
+	Failed []int
+	// Size is the final bin size.
+	Size Point[int]
+	// Occupancy is the ratio of the bin area covered by items.
+	Occupancy float64
+}
+
+// Packer packs rectangles into a bin, such as sprites into a texture atlas.
+type Packer struct {
+	opts   PackOptions
+	w, h   int
+	bin    bin
+	placed []Placement
+	used   int
+}
+
+// bin is the free space bookkeeping of a packing algorithm. Sizes include
+// the padding.
+type bin interface {
+	insert(w, h int, rotate bool) (x, y int, rotated, ok bool)
+	grow(w, h int)
+}
+
+// NewPacker creates a Packer for a bin of size w x h.
+func NewPacker(w, h int, opts PackOptions) *Packer {
+	if opts.PowerOfTwo {
+		w, h = pow2(w), pow2(h)
+	}
+	p := &Packer{opts: opts, w: w, h: h}
+	bw, bh := w+opts.Padding, h+opts.Padding
+	switch opts.Algorithm {
+	case Skyline:
+		p.bin = newSkylineBin(bw, bh)
+	case Guillotine:
+		p.bin = newGuillotineBin(bw, bh)
+	default:
+		p.bin = newMaxRectsBin(bw, bh, opts.Heuristic)
+	}
+	return p
+}
+
+// Size returns the current bin size.
+func (p *Packer) Size() Point[int] {
+	return Point[int]{p.w, p.h}
+}
+
+// Placements returns the items placed so far.
+func (p *Packer) Placements() []Placement {
+	return slices.Clone(p.placed)
+}
+
+// Occupancy returns the ratio of the bin area covered by items.
+func (p *Packer) Occupancy() float64 {
+	if p.w*p.h == 0 {
+		return 0
+	}
+	return float64(p.used) / float64(p.w*p.h)
+}
+
+// Insert places an item of size w x h, growing the bin if allowed. It
+// reports false if the item does not fit.
+func (p *Packer) Insert(w, h int) (r *Rect[int], rotated, ok bool) {
+	pl, ok := p.insert(len(p.placed), w, h)
+	return pl.Rect, pl.Rotated, ok
+}
+
+// Pack places all items at once, larger items first, and reports the
+// result. The items may be added to a bin that already holds items.
+func (p *Packer) Pack(sizes []Point[int]) PackReport {
+	idx := make([]int, len(sizes))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(i, j int) int {
+		a, b := sizes[i], sizes[j]
+		if d := max(b.X, b.Y) - max(a.X, a.Y); d != 0 {
+			return d
+		}
+		return b.X*b.Y - a.X*a.Y
+	})
+
+	var rep PackReport
+	for _, i := range idx {
+		if pl, ok := p.insert(i, sizes[i].X, sizes[i].Y); ok {
+			rep.Placements = append(rep.Placements, pl)
+		} else {
+			rep.Failed = append(rep.Failed, i)
+		}
+	}
+	slices.Sort(rep.Failed)
+	rep.Size = p.Size()
+	rep.Occupancy = p.Occupancy()
+	return rep
+}
+
+func (p *Packer) insert(index, w, h int) (Placement, bool) {
+	if w <= 0 || h <= 0 {
+		return Placement{}, false
+	}
+	pad := p.opts.Padding
+	for {
+		x, y, rotated, ok := p.bin.insert(w+pad, h+pad, p.opts.Rotate)
+		if ok {
+			pw, ph := w, h
+			if rotated {
+				pw, ph = h, w
+			}
+			pl := Placement{Index: index, Rect: XYWH(x, y, pw, ph), Rotated: rotated}
+			p.placed = append(p.placed, pl)
+			p.used += w * h
+			return pl, true
+		}
+		if !p.grow() {
+			return Placement{}, false
+		}
+	}
+}
+
+// grow doubles the shorter side of the bin within the limits. It reports
+// whether the bin grew.
+func (p *Packer) grow() bool {
+	mw, mh := p.opts.MaxWidth, p.opts.MaxHeight
+	canW, canH := p.w < mw, p.h < mh
+	if !canW && !canH {
+		return false
+	}
+	w, h := p.w, p.h
+	if canW && (!canH || w <= h) {
+		w = min(mw, max(1, w*2))
+	} else {
+		h = min(mh, max(1, h*2))
+	}
+	if p.opts.PowerOfTwo {
+		w, h = pow2(w), pow2(h)
+		if w > mw || h > mh {
+			return false
+		}
+	}
+	p.w, p.h = w, h
+	p.bin.grow(w+p.opts.Padding, h+p.opts.Padding)
+	return true
+}
+
+// pow2 returns the smallest power of two not less than n.
+func pow2(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// --------------------------------------
+// MaxRects
+// --------------------------------------
+
+type maxRectsBin struct {
+	w, h      int
+	heuristic PackHeuristic
+	free      []Rect[int]
+	used      []Rect[int]
+}
+
+func newMaxRectsBin(w, h int, heuristic PackHeuristic) *maxRectsBin {
+	return &maxRectsBin{
+		w:         w,
+		h:         h,
+		heuristic: heuristic,
+		free:      []Rect[int]{*WH(w, h)},
+	}
+}
+
+func (b *maxRectsBin) insert(w, h int, rotate bool) (x, y int, rotated, ok bool) {
+	best1, best2 := 0, 0
+	try := func(f Rect[int], w, h int, rot bool) {
+		if w > f.Dx() || h > f.Dy() {
+			return
+		}
+		s1, s2 := b.score(f, w, h)
+		if !ok || s1 < best1 || s1 == best1 && s2 < best2 {
+			x, y, rotated, ok = f.Min.X, f.Min.Y, rot, true
+			best1, best2 = s1, s2
+		}
+	}
+	for _, f := range b.free {
+		try(f, w, h, false)
+		if rotate && w != h {
+			try(f, h, w, true)
+		}
+	}
+	if !ok {
+		return 0, 0, false, false
+	}
+	if rotated {
+		w, h = h, w
+	}
+	b.place(*XYWH(x, y, w, h))
+	return x, y, rotated, true
+}
+
+// score returns the primary and secondary scores of placing a w x h item at
+// the corner of f. Lower scores are better.
+func (b *maxRectsBin) score(f Rect[int], w, h int) (int, int) {
+	dw, dh := f.Dx()-w, f.Dy()-h
+	switch b.heuristic {
+	case BestLongSideFit:
+		return max(dw, dh), min(dw, dh)
+	case BestAreaFit:
+		return f.Dx()*f.Dy() - w*h, min(dw, dh)
+	case BottomLeft:
+		return f.Min.Y + h, f.Min.X
+	case ContactPoint:
+		return -b.contact(*XYWH(f.Min.X, f.Min.Y, w, h)), 0
+	default:
+		return min(dw, dh), max(dw, dh)
+	}
+}
+
+// contact returns the length of r's edges touching the bin edges and the
+// placed items.
+func (b *maxRectsBin) contact(r Rect[int]) int {
+	n := 0
+	if r.Min.X == 0 || r.Max.X == b.w {
+		n += r.Dy()
+	}
+	if r.Min.Y == 0 || r.Max.Y == b.h {
+		n += r.Dx()
+	}
+	overlap := func(a0, a1, b0, b1 int) int {
+		return max(0, min(a1, b1)-max(a0, b0))
+	}
+	for _, u := range b.used {
+		if u.Max.X == r.Min.X || u.Min.X == r.Max.X {
+			n += overlap(r.Min.Y, r.Max.Y, u.Min.Y, u.Max.Y)
+		}
+		if u.Max.Y == r.Min.Y || u.Min.Y == r.Max.Y {
+			n += overlap(r.Min.X, r.Max.X, u.Min.X, u.Max.X)
+		}
+	}
+	return n
+}
+
+// place marks r as used and splits the free rectangles overlapping it.
+func (b *maxRectsBin) place(r Rect[int]) {
+	var free []Rect[int]
+	for _, f := range b.free {
+		if !f.Overlaps(&r) {
+			free = append(free, f)
+			continue
+		}
+		for _, s := range []*Rect[int]{
+			XYXY(f.Min.X, f.Min.Y, r.Min.X, f.Max.Y), // left
+			XYXY(r.Max.X, f.Min.Y, f.Max.X, f.Max.Y), // right
+			XYXY(f.Min.X, f.Min.Y, f.Max.X, r.Min.Y), // top
+			XYXY(f.Min.X, r.Max.Y, f.Max.X, f.Max.Y), // bottom
+		} {
+			if s.Min.X >= f.Min.X && s.Max.X <= f.Max.X &&
+				s.Min.Y >= f.Min.Y && s.Max.Y <= f.Max.Y && !s.Empty() {
+				free = append(free, *s)
+			}
+		}
+	}
+	b.free = prune(free)
+	b.used = append(b.used, r)
+}
+
+func (b *maxRectsBin) grow(w, h int) {
+	for i := range b.free {
+		f := &b.free[i]
+		if f.Max.X == b.w {
+			f.Max.X = w
+		}
+		if f.Max.Y == b.h {
+			f.Max.Y = h
+		}
+	}
+	if w > b.w {
+		b.free = append(b.free, *XYXY(b.w, 0, w, h))
+	}
+	if h > b.h {
+		b.free = append(b.free, *XYXY(0, b.h, w, h))
+	}
+	b.w, b.h = w, h
+	b.free = prune(b.free)
+}
+
+// prune removes the free rectangles contained in another one.
+func prune(free []Rect[int]) []Rect[int] {
+	var out []Rect[int]
+	for i, f := range free {
+		contained := false
+		for j, g := range free {
+			if i == j {
+				continue
+			}
+			if f.In(&g) && (!g.In(&f) || j < i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// --------------------------------------
+// Skyline
+// --------------------------------------
+
+type skylineBin struct {
+	w, h  int
+	nodes []skyNode
+}
+
+// A skyNode is a horizontal segment of the skyline.
+type skyNode struct {
+	x, y, w int
+}
+
+func newSkylineBin(w, h int) *skylineBin {
+	return &skylineBin{w: w, h: h, nodes: []skyNode{{0, 0, w}}}
+}
+
+func (b *skylineBin) insert(w, h int, rotate bool) (x, y int, rotated, ok bool) {
+	bestTop, bestX, bestI := 0, 0, -1
+	try := func(i, w, h int, rot bool) {
+		ny, fits := b.fit(i, w, h)
+		if !fits {
+			return
+		}
+		top := ny + h
+		if bestI < 0 || top < bestTop || top == bestTop && b.nodes[i].x < bestX {
+			bestTop, bestX, bestI = top, b.nodes[i].x, i
+			x, y, rotated = b.nodes[i].x, ny, rot
+		}
+	}
+	for i := range b.nodes {
+		try(i, w, h, false)
+		if rotate && w != h {
+			try(i, h, w, true)
+		}
+	}
+	if bestI < 0 {
+		return 0, 0, false, false
+	}
+	if rotated {
+		w, h = h, w
+	}
+	b.add(bestI, skyNode{x, y + h, w})
+	return x, y, rotated, true
+}
+
+// fit returns the y at which a w x h item fits on the skyline starting at
+// node i.
+func (b *skylineBin) fit(i, w, h int) (int, bool) {
+	x := b.nodes[i].x
+	if x+w > b.w {
+		return 0, false
+	}
+	y, left := 0, w
+	for ; left > 0 && i < len(b.nodes); i++ {
+		y = max(y, b.nodes[i].y)
+		left -= b.nodes[i].w
+	}
+	return y, y+h <= b.h
+}
+
+// add inserts n at index i and trims the nodes it covers.
+func (b *skylineBin) add(i int, n skyNode) {
+	b.nodes = slices.Insert(b.nodes, i, n)
+	for j := i + 1; j < len(b.nodes); {
+		prev, cur := b.nodes[j-1], &b.nodes[j]
+		shrink := prev.x + prev.w - cur.x
+		if shrink <= 0 {
+			break
+		}
+		cur.x += shrink
+		cur.w -= shrink
+		if cur.w > 0 {
+			break
+		}
+		b.nodes = slices.Delete(b.nodes, j, j+1)
+	}
+	b.merge()
+}
+
+func (b *skylineBin) merge() {
+	for i := 0; i+1 < len(b.nodes); {
+		if b.nodes[i].y == b.nodes[i+1].y {
+			b.nodes[i].w += b.nodes[i+1].w
+			b.nodes = slices.Delete(b.nodes, i+1, i+2)
+		} else {
+			i++
+		}
+	}
+}
+
+func (b *skylineBin) grow(w, h int) {
+	if w > b.w {
+		b.nodes = append(b.nodes, skyNode{b.w, 0, w - b.w})
+		b.merge()
+	}
+	b.w, b.h = w, h
+}
+
+// --------------------------------------
+// Guillotine
+// --------------------------------------
+
+type guillotineBin struct {
+	w, h int
+	free []Rect[int]
+}
+
+func newGuillotineBin(w, h int) *guillotineBin {
+	return &guillotineBin{w: w, h: h, free: []Rect[int]{*WH(w, h)}}
+}
+
+func (b *guillotineBin) insert(w, h int, rotate bool) (x, y int, rotated, ok bool) {
+	best, bestI := 0, -1
+	try := func(i, w, h int, rot bool) {
+		f := b.free[i]
+		if w > f.Dx() || h > f.Dy() {
+			return
+		}
+		if s := f.Dx()*f.Dy() - w*h; bestI < 0 || s < best {
+			best, bestI, rotated = s, i, rot
+		}
+	}
+	for i := range b.free {
+		try(i, w, h, false)
+		if rotate && w != h {
+			try(i, h, w, true)
+		}
+	}
+	if bestI < 0 {
+		return 0, 0, false, false
+	}
+	if rotated {
+		w, h = h, w
+	}
+	f := b.free[bestI]
+	b.free = slices.Delete(b.free, bestI, bestI+1)
+
+	// Split along the shorter axis of the free rectangle.
+	var right, bottom *Rect[int]
+	if f.Dx() < f.Dy() {
+		right = XYXY(f.Min.X+w, f.Min.Y, f.Max.X, f.Min.Y+h)
+		bottom = XYXY(f.Min.X, f.Min.Y+h, f.Max.X, f.Max.Y)
+	} else {
+		right = XYXY(f.Min.X+w, f.Min.Y, f.Max.X, f.Max.Y)
+		bottom = XYXY(f.Min.X, f.Min.Y+h, f.Min.X+w, f.Max.Y)
+	}
+	for _, r := range []*Rect[int]{right, bottom} {
+		if !r.Empty() {
+			b.free = append(b.free, *r)
+		}
+	}
+	return f.Min.X, f.Min.Y, rotated, true
+}
+
+// grow extends the free rectangles touching the right and bottom edges and
+// fills the rest of the new space with strips, keeping them disjoint.
+func (b *guillotineBin) grow(w, h int) {
+	if w > b.w {
+		var cover [][2]int
+		for i := range b.free {
+			if f := &b.free[i]; f.Max.X == b.w {
+				f.Max.X = w
+				cover = append(cover, [2]int{f.Min.Y, f.Max.Y})
+			}
+		}
+		for _, g := range uncovered(0, b.h, cover) {
+			b.free = append(b.free, *XYXY(b.w, g[0], w, g[1]))
+		}
+	}
+	if h > b.h {
+		var cover [][2]int
+		for i := range b.free {
+			if f := &b.free[i]; f.Max.Y == b.h {
+				f.Max.Y = h
+				cover = append(cover, [2]int{f.Min.X, f.Max.X})
+			}
+		}
+		for _, g := range uncovered(0, w, cover) {
+			b.free = append(b.free, *XYXY(g[0], b.h, g[1], h))
+		}
+	}
+	b.w, b.h = w, h
+}
+
+// uncovered returns the parts of [lo, hi) not covered by the disjoint spans.
+func uncovered(lo, hi int, spans [][2]int) [][2]int {
+	slices.SortFunc(spans, func(a, b [2]int) int { return a[0] - b[0] })
+	var out [][2]int
+	for _, s := range spans {
+		if s[0] > lo {
+			out = append(out, [2]int{lo, s[0]})
+		}
+		lo = max(lo, s[1])
+	}
+	if lo < hi {
+		out = append(out, [2]int{lo, hi})
+	}
+	return out
+}
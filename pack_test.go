@@ -0,0 +1,109 @@
+package align
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPacker(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	sizes := make([]Point[int], 200)
+	for i := range sizes {
+		sizes[i] = XY(4+rng.IntN(28), 4+rng.IntN(28))
+	}
+
+	tests := []struct {
+		name string
+		opts PackOptions
+	}{
+		{name: "MaxRects BSSF", opts: PackOptions{Algorithm: MaxRects}},
+		{name: "MaxRects BLSF", opts: PackOptions{Algorithm: MaxRects, Heuristic: BestLongSideFit}},
+		{name: "MaxRects BAF", opts: PackOptions{Algorithm: MaxRects, Heuristic: BestAreaFit}},
+		{name: "MaxRects BL", opts: PackOptions{Algorithm: MaxRects, Heuristic: BottomLeft}},
+		{name: "MaxRects CP", opts: PackOptions{Algorithm: MaxRects, Heuristic: ContactPoint, Rotate: true}},
+		{name: "Skyline", opts: PackOptions{Algorithm: Skyline, Rotate: true}},
+		{name: "Guillotine", opts: PackOptions{Algorithm: Guillotine, Rotate: true}},
+		{name: "Padding", opts: PackOptions{Padding: 2}},
+		{name: "Grow", opts: PackOptions{PowerOfTwo: true, MaxWidth: 1024, MaxHeight: 1024}},
+		{name: "Skyline grow", opts: PackOptions{Algorithm: Skyline, MaxWidth: 1024, MaxHeight: 1024}},
+		{name: "Guillotine grow", opts: PackOptions{Algorithm: Guillotine, MaxWidth: 1024, MaxHeight: 1024}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := 256, 256
+			if tt.opts.MaxWidth > 0 {
+				w, h = 32, 32
+			}
+			p := NewPacker(w, h, tt.opts)
+			rep := p.Pack(sizes)
+			bin := WH(rep.Size.X, rep.Size.Y)
+			pad := tt.opts.Padding
+
+			area := 0
+			for i, pl := range rep.Placements {
+				want := sizes[pl.Index]
+				if pl.Rotated {
+					want.X, want.Y = want.Y, want.X
+				}
+				if got := pl.Rect.Size(); !got.Eq(want) {
+					t.Fatalf("item %d has size %v, want %v", pl.Index, got, want)
+				}
+				if !pl.Rect.In(bin) {
+					t.Fatalf("item %d at %v is out of %v", pl.Index, pl.Rect, bin)
+				}
+				for _, q := range rep.Placements[i+1:] {
+					if pl.Rect.OutsetLTRB(0, 0, pad, pad).Overlaps(q.Rect) {
+						t.Fatalf("item %d at %v overlaps item %d at %v", pl.Index, pl.Rect, q.Index, q.Rect)
+					}
+				}
+				area += want.X * want.Y
+			}
+			if n := len(rep.Placements) + len(rep.Failed); n != len(sizes) {
+				t.Errorf("%d placed + failed, want %d", n, len(sizes))
+			}
+			if want := float64(area) / float64(bin.Dx()*bin.Dy()); rep.Occupancy != want {
+				t.Errorf("Occupancy = %v, want %v", rep.Occupancy, want)
+			}
+			if tt.opts.MaxWidth > 0 && len(rep.Failed) > 0 {
+				t.Errorf("%d items failed in a growing bin", len(rep.Failed))
+			}
+			if rep.Occupancy < .4 {
+				t.Errorf("Occupancy = %v is too low", rep.Occupancy)
+			}
+		})
+	}
+}
+
+func TestPackerInsert(t *testing.T) {
+	p := NewPacker(10, 10, PackOptions{Rotate: true})
+	if r, _, ok := p.Insert(10, 4); !ok || !r.Eq(XYWH(0, 0, 10, 4)) {
+		t.Errorf("Insert(10, 4) = %v, %v", r, ok)
+	}
+	if r, rotated, ok := p.Insert(6, 10); !ok || !rotated || !r.Eq(XYWH(0, 4, 10, 6)) {
+		t.Errorf("Insert(6, 10) = %v, %v, %v", r, rotated, ok)
+	}
+	if _, _, ok := p.Insert(1, 1); ok {
+		t.Error("Insert into a full bin succeeded")
+	}
+	if got := p.Occupancy(); got != 1 {
+		t.Errorf("Occupancy() = %v, want 1", got)
+	}
+}
+
+func TestPackerGrowLarge(t *testing.T) {
+	for _, algo := range []PackAlgorithm{MaxRects, Skyline, Guillotine} {
+		p := NewPacker(64, 64, PackOptions{Algorithm: algo, MaxWidth: 128, MaxHeight: 64})
+		if _, _, ok := p.Insert(20, 20); !ok {
+			t.Fatalf("algorithm %d: Insert(20, 20) failed", algo)
+		}
+		r, _, ok := p.Insert(100, 10)
+		if !ok {
+			t.Errorf("algorithm %d: Insert(100, 10) failed", algo)
+			continue
+		}
+		if r.Max.X > 128 || r.Max.Y > 64 || r.Overlaps(XYWH(0, 0, 20, 20)) {
+			t.Errorf("algorithm %d: Insert(100, 10) = %v", algo, r)
+		}
+	}
+}
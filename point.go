@@ -42,6 +42,16 @@ func (p Point[S]) Div(q Point[S]) Point[S] {
 	return Point[S]{p.X / q.X, p.Y / q.Y}
 }
 
+// Lerp returns the point at t between p (t=0) and q (t=1). Integer
+// coordinates are rounded to the nearest.
+func (p Point[S]) Lerp(q Point[S], t float64) Point[S] {
+	return Point[S]{lerp(p.X, q.X, t), lerp(p.Y, q.Y, t)}
+}
+
+func lerp[S ng.Scalar](a, b S, t float64) S {
+	return roundTo[S](float64(a)+(float64(b)-float64(a))*t, RoundHalfEven)
+}
+
 // In reports whether p is in r.
 func (p Point[S]) In(r Rect[S]) bool {
 	return r.Min.X <= p.X && p.X < r.Max.X &&
@@ -155,6 +155,22 @@ func WH[S ng.Scalar](w, h S) *Rect[S] {
 	}
 }
 
+// WithAspect creates the largest rectangle with the aspect ratio (width /
+// height) that fits within target, positioned at the relative position
+// (ax, ay).
+func WithAspect[S ng.Scalar](ratio float64, target Node[S], ax, ay float64) *Rect[S] {
+	w, h := aspectSize(ratio, target.Bounds(), false)
+	return WH(w, h).Nest(target, ax, ay)
+}
+
+// WithAspectCover creates the smallest rectangle with the aspect ratio
+// (width / height) that covers target, positioned at the relative position
+// (ax, ay).
+func WithAspectCover[S ng.Scalar](ratio float64, target Node[S], ax, ay float64) *Rect[S] {
+	w, h := aspectSize(ratio, target.Bounds(), true)
+	return WH(w, h).Nest(target, ax, ay)
+}
+
 // Clone returns a copy of the rectangle.
 func (r *Rect[S]) Clone() *Rect[S] {
 	return &Rect[S]{
@@ -216,8 +232,15 @@ func (r *Rect[S]) Shift(p Point[S]) {
 	r.Max.Y += p.Y
 }
 
+// Lerp returns a new rectangle at t between r (t=0) and s (t=1).
+func (r *Rect[S]) Lerp(s *Rect[S], t float64) *Rect[S] {
+	return &Rect[S]{r.Min.Lerp(s.Min, t), r.Max.Lerp(s.Max, t)}
+}
+
 // Anchor returns a point at the relative position (ax, ay) within r.
-// Values 0 and 1 represent Min and Max bounds respectively.
+// Values 0 and 1 represent Min and Max bounds respectively. The offset from
+// Min is truncated for integer scalars; see [Rect.AnchorRound] and [Layout]
+// for other rounding modes.
 func (r *Rect[S]) Anchor(ax, ay float64) Point[S] {
 	return Point[S]{
 		X: r.Min.X + S(float64(r.Max.X-r.Min.X)*ax),
@@ -262,6 +285,60 @@ func (r *Rect[S]) Clamp(s *Rect[S]) *Rect[S] {
 	return r
 }
 
+// Fit resizes r to the largest rectangle with r's aspect ratio that fits
+// within target, and positions it at the relative position (ax, ay).
+func (r *Rect[S]) Fit(target Node[S], ax, ay float64) *Rect[S] {
+	w, h := aspectSize(r.aspect(), target.Bounds(), false)
+	r.Max = Point[S]{r.Min.X + w, r.Min.Y + h}
+	return r.Nest(target, ax, ay)
+}
+
+// Cover resizes r to the smallest rectangle with r's aspect ratio that
+// covers target, and positions it at the relative position (ax, ay).
+func (r *Rect[S]) Cover(target Node[S], ax, ay float64) *Rect[S] {
+	w, h := aspectSize(r.aspect(), target.Bounds(), true)
+	r.Max = Point[S]{r.Min.X + w, r.Min.Y + h}
+	return r.Nest(target, ax, ay)
+}
+
+// aspect returns the aspect ratio (width / height) of r, or 0 if r is empty.
+func (r *Rect[S]) aspect() float64 {
+	if r.Empty() {
+		return 0
+	}
+	return float64(r.Dx()) / float64(r.Dy())
+}
+
+// aspectSize returns the size of the largest rectangle with the given aspect
+// ratio that fits within t, or the smallest one that covers t. Integer sizes
+// are rounded toward t so that a fitted rectangle never exceeds it and a
+// covering rectangle never falls short of it.
+func aspectSize[S ng.Scalar](ratio float64, t *Rect[S], cover bool) (w, h S) {
+	if !(ratio > 0) || math.IsInf(ratio, 0) {
+		return 0, 0
+	}
+	tw, th := float64(t.Dx()), float64(t.Dy())
+	fw, fh := th*ratio, th
+	if (fw > tw) != cover {
+		fw, fh = tw, tw/ratio
+	}
+	if isInt[S]() {
+		const eps = 1e-9
+		if cover {
+			fw, fh = math.Ceil(fw-eps), math.Ceil(fh-eps)
+		} else {
+			fw, fh = math.Floor(fw+eps), math.Floor(fh+eps)
+		}
+	}
+	return S(fw), S(fh)
+}
+
+// isInt reports whether S is an integer type.
+func isInt[S ng.Scalar]() bool {
+	half := 0.5
+	return S(half) == 0
+}
+
 // InsetXY returns r inset by x horizontally and y vertically.
 func (r *Rect[S]) InsetXY(x, y S) *Rect[S] {
 	return r.InsetLTRB(x, y, x, y)
@@ -335,9 +412,129 @@ func (r *Rect[S]) CutYByRate(rate float64) (top, bottom *Rect[S]) {
 	return r.CutY(h)
 }
 
+// Side identifies an edge of a rectangle.
+type Side int
+
+const (
+	// Left is the Min.X edge.
+	Left Side = iota
+	// Top is the Min.Y edge.
+	Top
+	// Right is the Max.X edge.
+	Right
+	// Bottom is the Max.Y edge.
+	Bottom
+)
+
+// Cut divides r into the rectangle of size n along the given side and the
+// remaining rectangle. n is clamped to r's dimensions.
+func (r *Rect[S]) Cut(side Side, n S) (cut, rest *Rect[S]) {
+	switch side {
+	case Top:
+		return r.CutY(n)
+	case Right:
+		n = max(0, min(r.Dx(), n))
+		rest, cut = r.CutX(r.Dx() - n)
+		return cut, rest
+	case Bottom:
+		n = max(0, min(r.Dy(), n))
+		rest, cut = r.CutY(r.Dy() - n)
+		return cut, rest
+	default:
+		return r.CutX(n)
+	}
+}
+
+// CutByRate divides r at the given rate (0.0 to 1.0) measured from side.
+func (r *Rect[S]) CutByRate(side Side, rate float64) (cut, rest *Rect[S]) {
+	return r.Cut(side, r.sideLen(side, rate))
+}
+
+// CutLeft divides r into the left rectangle of width w and the rest.
+func (r *Rect[S]) CutLeft(w S) (left, rest *Rect[S]) {
+	return r.Cut(Left, w)
+}
+
+// CutTop divides r into the top rectangle of height h and the rest.
+func (r *Rect[S]) CutTop(h S) (top, rest *Rect[S]) {
+	return r.Cut(Top, h)
+}
+
+// CutRight divides r into the right rectangle of width w and the rest.
+func (r *Rect[S]) CutRight(w S) (right, rest *Rect[S]) {
+	return r.Cut(Right, w)
+}
+
+// CutBottom divides r into the bottom rectangle of height h and the rest.
+func (r *Rect[S]) CutBottom(h S) (bottom, rest *Rect[S]) {
+	return r.Cut(Bottom, h)
+}
+
+// Take removes the rectangle of size n along the given side from r, followed
+// by gap, and returns the removed rectangle. r shrinks in place.
+func (r *Rect[S]) Take(side Side, n, gap S) *Rect[S] {
+	cut, _ := r.Cut(side, n)
+	_, rest := r.Cut(side, n+gap)
+	*r = *rest
+	return cut
+}
+
+// TakeByRate is like [Rect.Take] but measures n as a rate (0.0 to 1.0) of
+// r's size.
+func (r *Rect[S]) TakeByRate(side Side, rate float64, gap S) *Rect[S] {
+	return r.Take(side, r.sideLen(side, rate), gap)
+}
+
+// TakeLeft removes a strip of width w and then gap from the left of r, and
+// returns the strip.
+func (r *Rect[S]) TakeLeft(w, gap S) *Rect[S] {
+	return r.Take(Left, w, gap)
+}
+
+// TakeTop removes a strip of height h and then gap from the top of r, and
+// returns the strip.
+func (r *Rect[S]) TakeTop(h, gap S) *Rect[S] {
+	return r.Take(Top, h, gap)
+}
+
+// TakeRight removes a strip of width w and then gap from the right of r, and
+// returns the strip.
+func (r *Rect[S]) TakeRight(w, gap S) *Rect[S] {
+	return r.Take(Right, w, gap)
+}
+
+// TakeBottom removes a strip of height h and then gap from the bottom of r,
+// and returns the strip.
+func (r *Rect[S]) TakeBottom(h, gap S) *Rect[S] {
+	return r.Take(Bottom, h, gap)
+}
+
+// sideLen returns rate times r's size along the axis of side.
+func (r *Rect[S]) sideLen(side Side, rate float64) S {
+	if side == Left || side == Right {
+		return S(float64(r.Dx()) * rate)
+	}
+	return S(float64(r.Dy()) * rate)
+}
+
 // Split divides the rectangle r into a grid of rectangles with xs columns and
-// ys rows, with gaps of xGap and yGap between them.
+// ys rows, with gaps of xGap and yGap between them. When the space does not
+// divide evenly, the remainder is spread across the cells so that the cells
+// and gaps tile r exactly.
 func (r *Rect[S]) Split(xs, ys int, xGap, yGap S) Slice[S] {
+	return r.split(xs, ys, xGap, yGap, func(total S, i, n int) S {
+		if !isInt[S]() {
+			return total * S(i) / S(n)
+		}
+		// Avoid overflowing total * i in narrow integer types.
+		q, rem := int64(total)/int64(n), int64(total)%int64(n)
+		return S(q*int64(i) + rem*int64(i)/int64(n))
+	})
+}
+
+// split divides r into a grid. part returns the offset of the i-th of n
+// cell boundaries within total, excluding gaps.
+func (r *Rect[S]) split(xs, ys int, xGap, yGap S, part func(total S, i, n int) S) Slice[S] {
 	if xs <= 0 || ys <= 0 {
 		return nil
 	}
@@ -345,16 +542,16 @@ func (r *Rect[S]) Split(xs, ys int, xGap, yGap S) Slice[S] {
 		return Slice[S]{r}
 	}
 
-	w := (r.Dx() - S(xGap)*(S(xs)-1)) / S(xs)
-	h := (r.Dy() - S(yGap)*(S(ys)-1)) / S(ys)
+	w := r.Dx() - xGap*S(xs-1)
+	h := r.Dy() - yGap*S(ys-1)
 
 	rs := make(Slice[S], 0, xs*ys)
 	for y := range ys {
+		minY := r.Min.Y + S(y)*yGap + part(h, y, ys)
+		maxY := r.Min.Y + S(y)*yGap + part(h, y+1, ys)
 		for x := range xs {
-			minX := r.Min.X + S(x)*(w+xGap)
-			minY := r.Min.Y + S(y)*(h+yGap)
-			maxX := minX + w
-			maxY := minY + h
+			minX := r.Min.X + S(x)*xGap + part(w, x, xs)
+			maxX := r.Min.X + S(x)*xGap + part(w, x+1, xs)
 			rs = append(rs, XYXY(minX, minY, maxX, maxY))
 		}
 	}
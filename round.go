@@ -0,0 +1,134 @@
+package align
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Rounding specifies how fractional coordinates are converted to integer
+// scalars. Float scalars are never rounded.
+type Rounding int
+
+const (
+	// RoundTrunc rounds toward zero. This is the conversion used by the
+	// plain [Rect] methods.
+	RoundTrunc Rounding = iota
+	// RoundHalfEven rounds to the nearest integer, with ties to even.
+	RoundHalfEven
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+)
+
+// Round rounds f according to m.
+func (m Rounding) Round(f float64) float64 {
+	switch m {
+	case RoundHalfEven:
+		return math.RoundToEven(f)
+	case RoundFloor:
+		return math.Floor(f)
+	case RoundCeil:
+		return math.Ceil(f)
+	default:
+		return math.Trunc(f)
+	}
+}
+
+// roundTo converts f to S, rounding according to m if S is an integer type.
+func roundTo[S ng.Scalar](f float64, m Rounding) S {
+	if isInt[S]() {
+		f = m.Round(f)
+	}
+	return S(f)
+}
+
+// AnchorRound is like [Rect.Anchor] but rounds the offset from r.Min
+// according to m.
+func (r *Rect[S]) AnchorRound(ax, ay float64, m Rounding) Point[S] {
+	return Point[S]{
+		X: r.Min.X + roundTo[S](float64(r.Max.X-r.Min.X)*ax, m),
+		Y: r.Min.Y + roundTo[S](float64(r.Max.Y-r.Min.Y)*ay, m),
+	}
+}
+
+// Snap rounds r's coordinates to the nearest multiple of grid, with ties to
+// even. It does nothing if grid is not positive.
+func (r *Rect[S]) Snap(grid S) *Rect[S] {
+	return r.snap(grid, RoundHalfEven)
+}
+
+func (r *Rect[S]) snap(grid S, m Rounding) *Rect[S] {
+	if grid <= 0 {
+		return r
+	}
+	g := float64(grid)
+	f := func(v S) S {
+		return S(m.Round(float64(v)/g) * g)
+	}
+	r.Min = Point[S]{f(r.Min.X), f(r.Min.Y)}
+	r.Max = Point[S]{f(r.Max.X), f(r.Max.Y)}
+	return r
+}
+
+// Layout performs layout operations with a shared rounding mode.
+// The zero value truncates like the plain [Rect] methods.
+type Layout[S ng.Scalar] struct {
+	Rounding Rounding
+}
+
+// Anchor returns a point at the relative position (ax, ay) within r.
+func (l Layout[S]) Anchor(r *Rect[S], ax, ay float64) Point[S] {
+	return r.AnchorRound(ax, ay, l.Rounding)
+}
+
+// Align aligns r's anchor point (ax, ay) to target's anchor point (tax, tay).
+func (l Layout[S]) Align(r *Rect[S], ax, ay float64, target Node[S], tax, tay float64) *Rect[S] {
+	tb := target.Bounds()
+	d := l.Anchor(tb, tax, tay).Sub(l.Anchor(r, ax, ay))
+	return r.Add(d)
+}
+
+// CenterOf centers r within target.
+func (l Layout[S]) CenterOf(r *Rect[S], target Node[S]) *Rect[S] {
+	return l.Align(r, 0.5, 0.5, target, 0.5, 0.5)
+}
+
+// Nest positions r within target at the relative position (ax, ay).
+func (l Layout[S]) Nest(r *Rect[S], target Node[S], ax, ay float64) *Rect[S] {
+	return l.Align(r, ax, ay, target, ax, ay)
+}
+
+// StackX stacks r horizontally against target at the relative position (tax, tay).
+func (l Layout[S]) StackX(r *Rect[S], target Node[S], tax, tay float64) *Rect[S] {
+	return l.Align(r, 1-tax, tay, target, tax, tay)
+}
+
+// StackY stacks r vertically against target at the relative position (tax, tay).
+func (l Layout[S]) StackY(r *Rect[S], target Node[S], tax, tay float64) *Rect[S] {
+	return l.Align(r, tax, 1-tay, target, tax, tay)
+}
+
+// CutXByRate divides r horizontally at the given rate (0.0 to 1.0).
+func (l Layout[S]) CutXByRate(r *Rect[S], rate float64) (left, right *Rect[S]) {
+	return r.CutX(roundTo[S](float64(r.Dx())*rate, l.Rounding))
+}
+
+// CutYByRate divides r vertically at the given rate (0.0 to 1.0).
+func (l Layout[S]) CutYByRate(r *Rect[S], rate float64) (top, bottom *Rect[S]) {
+	return r.CutY(roundTo[S](float64(r.Dy())*rate, l.Rounding))
+}
+
+// Split divides r into a grid like [Rect.Split], rounding the cell
+// boundaries according to l. The cells and gaps always tile r exactly.
+func (l Layout[S]) Split(r *Rect[S], xs, ys int, xGap, yGap S) Slice[S] {
+	return r.split(xs, ys, xGap, yGap, func(total S, i, n int) S {
+		return roundTo[S](float64(total)*float64(i)/float64(n), l.Rounding)
+	})
+}
+
+// Snap rounds r's coordinates to multiples of grid according to l.
+func (l Layout[S]) Snap(r *Rect[S], grid S) *Rect[S] {
+	return r.snap(grid, l.Rounding)
+}
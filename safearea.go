@@ -0,0 +1,116 @@
+package align
+
+import "github.com/eihigh/ng"
+
+// SafeArea describes the parts of a screen hidden by notches, rounded
+// corners, system bars or TV overscan. Content is laid out in the safe
+// rectangle while backgrounds may still extend to the full screen.
+type SafeArea[S ng.Scalar] struct {
+	// Left, Top, Right and Bottom are the insets from the screen edges.
+	Left, Top, Right, Bottom S
+	// Cutouts are additional unsafe rectangles in screen coordinates, such as
+	// a camera hole. The safe rectangle is shrunk from the nearest edge to
+	// exclude them.
+	Cutouts []*Rect[S]
+}
+
+// Overscan returns a SafeArea that insets each edge of screen by rate of its
+// size, such as 0.05 for the title-safe area of a TV.
+func Overscan[S ng.Scalar](screen *Rect[S], rate float64) SafeArea[S] {
+	x := roundTo[S](float64(screen.Dx())*rate, RoundCeil)
+	y := roundTo[S](float64(screen.Dy())*rate, RoundCeil)
+	return SafeArea[S]{Left: x, Top: y, Right: x, Bottom: y}
+}
+
+// SafeAreaPresets holds the safe area insets of common devices in points.
+// Use [SafeAreaPreset] to scale them to pixels.
+var SafeAreaPresets = map[string]SafeArea[float64]{
+	"iphone-home-button":              {Top: 20},
+	"iphone-notch":                    {Top: 47, Bottom: 34},
+	"iphone-notch-landscape":          {Left: 47, Right: 47, Bottom: 21},
+	"iphone-dynamic-island":           {Top: 59, Bottom: 34},
+	"iphone-dynamic-island-landscape": {Left: 59, Right: 59, Bottom: 21},
+	"ipad":                            {Top: 24, Bottom: 20},
+}
+
+// SafeAreaPreset returns the preset of the given name in [SafeAreaPresets]
+// multiplied by scale, such as the device pixel ratio. The insets are rounded
+// up.
+func SafeAreaPreset[S ng.Scalar](name string, scale float64) (SafeArea[S], bool) {
+	p, ok := SafeAreaPresets[name]
+	if !ok {
+		return SafeArea[S]{}, false
+	}
+	a := SafeArea[S]{
+		Left:   roundTo[S](p.Left*scale, RoundCeil),
+		Top:    roundTo[S](p.Top*scale, RoundCeil),
+		Right:  roundTo[S](p.Right*scale, RoundCeil),
+		Bottom: roundTo[S](p.Bottom*scale, RoundCeil),
+	}
+	for _, c := range p.Cutouts {
+		a.Cutouts = append(a.Cutouts, XYXY(
+			roundTo[S](c.Min.X*scale, RoundFloor), roundTo[S](c.Min.Y*scale, RoundFloor),
+			roundTo[S](c.Max.X*scale, RoundCeil), roundTo[S](c.Max.Y*scale, RoundCeil),
+		))
+	}
+	return a, true
+}
+
+// Rect returns the safe rectangle of screen: screen inset as with
+// [Rect.InsetLTRB], then shrunk to exclude the cutouts.
+func (a SafeArea[S]) Rect(screen *Rect[S]) *Rect[S] {
+	r := screen.InsetLTRB(a.Left, a.Top, a.Right, a.Bottom)
+	for _, c := range a.Cutouts {
+		if !r.Overlaps(c) {
+			continue
+		}
+		// Cut from the side that loses the least.
+		cuts := [4]S{
+			Left:   c.Max.X - r.Min.X,
+			Top:    c.Max.Y - r.Min.Y,
+			Right:  r.Max.X - c.Min.X,
+			Bottom: r.Max.Y - c.Min.Y,
+		}
+		side := Left
+		for s := range cuts {
+			if cuts[s] < cuts[side] {
+				side = Side(s)
+			}
+		}
+		_, r = r.Cut(side, cuts[side])
+	}
+	return r
+}
+
+// Nest positions n within the safe rectangle of screen at the relative
+// position (ax, ay), like [Rect.Nest].
+func (a SafeArea[S]) Nest(n Node[S], screen *Rect[S], ax, ay float64) {
+	Slice[S]{n}.Nest(a.Rect(screen), ax, ay)
+}
+
+// Clamp moves n into the safe rectangle of screen while keeping its size,
+// like [Rect.Clamp].
+func (a SafeArea[S]) Clamp(n Node[S], screen *Rect[S]) {
+	Slice[S]{n}.Clamp(a.Rect(screen))
+}
+
+// Bleed returns r with each edge that reaches the edge of the safe rectangle
+// of screen extended to the edge of screen, for backgrounds of bars and
+// panels laid out in the safe rectangle.
+func (a SafeArea[S]) Bleed(r *Rect[S], screen *Rect[S]) *Rect[S] {
+	safe := a.Rect(screen)
+	b := r.Clone()
+	if b.Min.X <= safe.Min.X {
+		b.Min.X = min(b.Min.X, screen.Min.X)
+	}
+	if b.Min.Y <= safe.Min.Y {
+		b.Min.Y = min(b.Min.Y, screen.Min.Y)
+	}
+	if b.Max.X >= safe.Max.X {
+		b.Max.X = max(b.Max.X, screen.Max.X)
+	}
+	if b.Max.Y >= safe.Max.Y {
+		b.Max.Y = max(b.Max.Y, screen.Max.Y)
+	}
+	return b
+}
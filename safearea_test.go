@@ -0,0 +1,37 @@
+package align
+
+import "testing"
+
+func TestSafeArea(t *testing.T) {
+	screen := WH(400, 800)
+	notch := SafeArea[int]{Top: 40, Bottom: 30}
+	hole := SafeArea[int]{Cutouts: []*Rect[int]{XYWH(180, 10, 40, 30)}}
+	tv := Overscan(WH(1920, 1080), 0.05)
+	preset, ok := SafeAreaPreset[int]("iphone-notch", 3)
+	if !ok {
+		t.Fatal("preset not found")
+	}
+
+	bar := WH(100, 20)
+	notch.Nest(bar, screen, 0.5, 0)
+	header := XYWH(0, 40, 400, 50)
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "insets", got: notch.Rect(screen), want: XYXY(0, 40, 400, 770)},
+		{name: "cutout", got: hole.Rect(screen), want: XYXY(0, 40, 400, 800)},
+		{name: "overscan", got: tv.Rect(WH(1920, 1080)), want: XYXY(96, 54, 1824, 1026)},
+		{name: "preset", got: preset.Rect(WH(1170, 2532)), want: XYXY(0, 141, 1170, 2430)},
+		{name: "nest", got: bar, want: XYWH(150, 40, 100, 20)},
+		{name: "bleed", got: notch.Bleed(header, screen), want: XYXY(0, 0, 400, 90)},
+		{name: "no bleed", got: notch.Bleed(XYWH(10, 100, 50, 50), screen), want: XYWH(10, 100, 50, 50)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
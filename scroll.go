@@ -0,0 +1,134 @@
+package align
+
+import (
+	"iter"
+
+	"github.com/eihigh/ng"
+)
+
+// ScrollView shows a content node through a viewport. The content is kept
+// in screen coordinates: scrolling shifts the content node itself, so its
+// descendants can be hit-tested and drawn directly.
+type ScrollView[S ng.Scalar] struct {
+	viewport *Rect[S]
+	content  Node[S]
+	offset   Point[S]
+}
+
+// NewScrollView creates a ScrollView that nests content at the top-left
+// corner of viewport.
+func NewScrollView[S ng.Scalar](viewport *Rect[S], content Node[S]) *ScrollView[S] {
+	v := &ScrollView[S]{
+		viewport: viewport.Clone(),
+		content:  content,
+	}
+	content.Shift(v.viewport.Min.Sub(content.Bounds().Min))
+	return v
+}
+
+// Viewport returns a copy of the viewport rectangle.
+func (v *ScrollView[S]) Viewport() *Rect[S] {
+	return v.viewport.Clone()
+}
+
+// Content returns the content node.
+func (v *ScrollView[S]) Content() Node[S] {
+	return v.content
+}
+
+// Offset returns the scroll offset, the distance the content is scrolled
+// from its initial position.
+func (v *ScrollView[S]) Offset() Point[S] {
+	return v.offset
+}
+
+// MaxOffset returns the largest scroll offset that keeps the content
+// covering the viewport.
+func (v *ScrollView[S]) MaxOffset() Point[S] {
+	c := v.content.Bounds()
+	return Point[S]{
+		X: sub0(c.Dx(), v.viewport.Dx()),
+		Y: sub0(c.Dy(), v.viewport.Dy()),
+	}
+}
+
+// SetOffset scrolls to the offset p, clamped to [0, MaxOffset].
+func (v *ScrollView[S]) SetOffset(p Point[S]) {
+	m := v.MaxOffset()
+	p.X = max(0, min(m.X, p.X))
+	p.Y = max(0, min(m.Y, p.Y))
+	v.content.Shift(v.offset.Sub(p))
+	v.offset = p
+}
+
+// ScrollBy scrolls by d, clamped like [ScrollView.SetOffset].
+func (v *ScrollView[S]) ScrollBy(d Point[S]) {
+	v.SetOffset(v.offset.Add(d))
+}
+
+// ScrollTo scrolls so that n, a descendant of the content, is positioned at
+// the relative position (ax, ay) in the viewport as with [Rect.Nest], as far
+// as the scroll range allows.
+func (v *ScrollView[S]) ScrollTo(n Node[S], ax, ay float64) {
+	b := n.Bounds()
+	d := b.Clone().Nest(v.viewport, ax, ay).Min.Sub(b.Min)
+	v.SetOffset(v.offset.Sub(d))
+}
+
+// ScrollIntoView scrolls by the least amount that brings n, a descendant of the
+// content, fully into view. If n is larger than the viewport, its top-left
+// corner is shown.
+func (v *ScrollView[S]) ScrollIntoView(n Node[S]) {
+	b, vp := n.Bounds(), v.viewport
+	d := Point[S]{}
+	if b.Max.X > vp.Max.X {
+		d.X = b.Max.X - vp.Max.X
+	}
+	if b.Min.X-d.X < vp.Min.X {
+		d.X = b.Min.X - vp.Min.X
+	}
+	if b.Max.Y > vp.Max.Y {
+		d.Y = b.Max.Y - vp.Max.Y
+	}
+	if b.Min.Y-d.Y < vp.Min.Y {
+		d.Y = b.Min.Y - vp.Min.Y
+	}
+	v.SetOffset(v.offset.Add(d))
+}
+
+// Visible returns an iterator over the nodes of s that overlap the viewport,
+// with their indices.
+func (v *ScrollView[S]) Visible(s Slice[S]) iter.Seq2[int, Node[S]] {
+	return func(yield func(int, Node[S]) bool) {
+		for i, n := range s {
+			if n.Bounds().Overlaps(v.viewport) && !yield(i, n) {
+				return
+			}
+		}
+	}
+}
+
+// ThumbX returns the horizontal scrollbar thumb within track, at least
+// minLen long.
+func (v *ScrollView[S]) ThumbX(track *Rect[S], minLen S) *Rect[S] {
+	x, w := thumb(track.Dx(), v.viewport.Dx(), v.content.Bounds().Dx(), v.offset.X, minLen)
+	return XYXY(track.Min.X+x, track.Min.Y, track.Min.X+x+w, track.Max.Y)
+}
+
+// ThumbY returns the vertical scrollbar thumb within track, at least minLen
+// long.
+func (v *ScrollView[S]) ThumbY(track *Rect[S], minLen S) *Rect[S] {
+	y, h := thumb(track.Dy(), v.viewport.Dy(), v.content.Bounds().Dy(), v.offset.Y, minLen)
+	return XYXY(track.Min.X, track.Min.Y+y, track.Max.X, track.Min.Y+y+h)
+}
+
+// thumb returns the position and length of a scrollbar thumb in a track.
+func thumb[S ng.Scalar](track, view, content, offset, minLen S) (pos, length S) {
+	if content <= view {
+		return 0, track
+	}
+	length = S(float64(track) * float64(view) / float64(content))
+	length = min(track, max(minLen, length))
+	pos = S(float64(track-length) * float64(offset) / float64(content-view))
+	return pos, length
+}
@@ -0,0 +1,44 @@
+package align
+
+import "testing"
+
+func TestScrollView(t *testing.T) {
+	items := WH(50, 10).RepeatY(20, 0) // 200 high
+	v := NewScrollView(XYWH(10, 10, 50, 40), Node[int](items))
+
+	if got, want := items[0].Bounds(), XYWH(10, 10, 50, 10); !got.Eq(want) {
+		t.Errorf("first item = %v, want %v", got, want)
+	}
+	if got, want := v.MaxOffset(), XY(0, 160); !got.Eq(want) {
+		t.Errorf("MaxOffset() = %v, want %v", got, want)
+	}
+
+	v.ScrollBy(XY(5, -5))
+	if got := v.Offset(); !got.Eq(XY(0, 0)) {
+		t.Errorf("Offset() after clamped ScrollBy = %v", got)
+	}
+
+	v.ScrollTo(items[10], .5, .5) // item 10 centered
+	if got, want := v.Offset(), XY(0, 85); !got.Eq(want) {
+		t.Errorf("Offset() after ScrollTo = %v, want %v", got, want)
+	}
+	if got, want := items[10].Bounds(), XYWH(10, 25, 50, 10); !got.Eq(want) {
+		t.Errorf("item 10 = %v, want %v", got, want)
+	}
+
+	var visible []int
+	for i := range v.Visible(items) {
+		visible = append(visible, i)
+	}
+	if len(visible) != 5 || visible[0] != 8 {
+		t.Errorf("Visible() = %v, want [8 9 10 11 12]", visible)
+	}
+
+	v.ScrollIntoView(items[19])
+	if got, want := v.Offset(), XY(0, 160); !got.Eq(want) {
+		t.Errorf("Offset() after ScrollIntoView = %v, want %v", got, want)
+	}
+	if got, want := v.ThumbY(XYWH(60, 10, 4, 40), 4), XYWH(60, 42, 4, 8); !got.Eq(want) {
+		t.Errorf("ThumbY() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+package align
+
+import (
+	"iter"
+
+	"github.com/eihigh/ng"
+)
+
+// A Size is the length of a cell along the split axis, given by [Fixed],
+// [Rate] or [Fr].
+type Size struct {
+	kind  sizeKind
+	value float64
+}
+
+type sizeKind int
+
+const (
+	sizeFixed sizeKind = iota
+	sizeRate
+	sizeFr
+)
+
+// Fixed returns a Size of n units.
+func Fixed(n float64) Size {
+	return Size{sizeFixed, n}
+}
+
+// Rate returns a Size of the given rate (0.0 to 1.0) of the space
+// available to the cells, excluding gaps.
+func Rate(rate float64) Size {
+	return Size{sizeRate, rate}
+}
+
+// Fr returns a Size of n fractional shares of the space left over after
+// the Fixed and Rate cells are placed.
+func Fr(n float64) Size {
+	return Size{sizeFr, n}
+}
+
+// SplitXWeights divides r into columns whose widths are proportional to
+// weights, with xGap spacing between them. The columns and gaps tile r
+// exactly.
+func (r *Rect[S]) SplitXWeights(weights []float64, xGap S) Slice[S] {
+	return r.SplitXSizes(frs(weights), xGap)
+}
+
+// SplitYWeights divides r into rows whose heights are proportional to
+// weights, with yGap spacing between them. The rows and gaps tile r exactly.
+func (r *Rect[S]) SplitYWeights(weights []float64, yGap S) Slice[S] {
+	return r.SplitYSizes(frs(weights), yGap)
+}
+
+// SplitXSizes divides r into columns of the given sizes, with xGap spacing
+// between them. If the last size is [Fr], the columns and gaps tile r
+// exactly. If the sizes overflow r, the columns are clipped to r, so the
+// columns past its right edge are empty.
+func (r *Rect[S]) SplitXSizes(sizes []Size, xGap S) Slice[S] {
+	if len(sizes) == 0 {
+		return nil
+	}
+	rs := make(Slice[S], 0, len(sizes))
+	for x0, x1 := range spans(r.Dx(), sizes, xGap) {
+		rs = append(rs, XYXY(r.Min.X+x0, r.Min.Y, r.Min.X+x1, r.Max.Y))
+	}
+	return rs
+}
+
+// SplitYSizes divides r into rows of the given sizes, with yGap spacing
+// between them. If the last size is [Fr], the rows and gaps tile r exactly.
+// If the sizes overflow r, the rows are clipped to r, so the rows past its
+// bottom edge are empty.
+func (r *Rect[S]) SplitYSizes(sizes []Size, yGap S) Slice[S] {
+	if len(sizes) == 0 {
+		return nil
+	}
+	rs := make(Slice[S], 0, len(sizes))
+	for y0, y1 := range spans(r.Dy(), sizes, yGap) {
+		rs = append(rs, XYXY(r.Min.X, r.Min.Y+y0, r.Max.X, r.Min.Y+y1))
+	}
+	return rs
+}
+
+func frs(weights []float64) []Size {
+	sizes := make([]Size, len(weights))
+	for i, w := range weights {
+		sizes[i] = Fr(w)
+	}
+	return sizes
+}
+
+// spans resolves sizes within total and yields the start and end offset of
+// each cell. Cell boundaries are truncated from their exact positions, so
+// that integer cells differ from their ideal sizes by less than one unit.
+// Offsets are clamped to [0, total] and Fr cells are empty on overflow.
+func spans[S ng.Scalar](total S, sizes []Size, gap S) iter.Seq2[S, S] {
+	return func(yield func(S, S) bool) {
+		avail := float64(total) - float64(gap)*float64(len(sizes)-1)
+		fixed, shares := 0.0, 0.0
+		for _, s := range sizes {
+			switch s.kind {
+			case sizeFixed:
+				fixed += s.value
+			case sizeRate:
+				fixed += s.value * avail
+			case sizeFr:
+				shares += s.value
+			}
+		}
+		rest := max(0, avail-fixed)
+
+		pos, end := 0.0, S(0)
+		for i, s := range sizes {
+			switch s.kind {
+			case sizeFixed:
+				pos += s.value
+			case sizeRate:
+				pos += s.value * avail
+			case sizeFr:
+				if shares > 0 {
+					pos += rest * s.value / shares
+				}
+			}
+			start := end
+			end = S(pos)
+			if i == len(sizes)-1 && s.kind == sizeFr && shares > 0 {
+				end = total - gap*S(i) // avoid floating-point drift
+			}
+			lo := min(max(start+gap*S(i), 0), total)
+			hi := min(max(end+gap*S(i), lo), total)
+			if !yield(lo, hi) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package align
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/eihigh/ng"
+)
+
+// A Pane is a node of a [SplitPane] tree. It is either a leaf or a split of
+// its space into the two panes A and B separated by a divider.
+type Pane[S ng.Scalar] struct {
+	// A and B are the child panes of a split, or nil for a leaf.
+	A, B *Pane[S]
+	// Vertical places A above B instead of left of B.
+	Vertical bool
+	// Ratio is the rate (0.0 to 1.0) of the space, excluding the divider,
+	// given to A.
+	Ratio float64
+	// Min and Max limit the size of the pane along its parent's split axis.
+	// A zero Max means no limit.
+	Min, Max S
+
+	rect, divider *Rect[S]
+}
+
+// NewPane creates a leaf pane.
+func NewPane[S ng.Scalar]() *Pane[S] {
+	return &Pane[S]{}
+}
+
+// SplitH creates a pane split into a on the left and b on the right.
+func SplitH[S ng.Scalar](ratio float64, a, b *Pane[S]) *Pane[S] {
+	return &Pane[S]{A: a, B: b, Ratio: ratio}
+}
+
+// SplitV creates a pane split into a on the top and b on the bottom.
+func SplitV[S ng.Scalar](ratio float64, a, b *Pane[S]) *Pane[S] {
+	return &Pane[S]{A: a, B: b, Ratio: ratio, Vertical: true}
+}
+
+// Limit sets the size limits of p and returns p.
+func (p *Pane[S]) Limit(lo, hi S) *Pane[S] {
+	p.Min, p.Max = lo, hi
+	return p
+}
+
+// IsLeaf reports whether p is a leaf pane.
+func (p *Pane[S]) IsLeaf() bool {
+	return p.A == nil || p.B == nil
+}
+
+// Rect returns the space of p as of the last layout.
+func (p *Pane[S]) Rect() *Rect[S] {
+	if p.rect == nil {
+		return &Rect[S]{}
+	}
+	return p.rect.Clone()
+}
+
+// Divider returns the divider of a split pane as of the last layout, or nil
+// for a leaf.
+func (p *Pane[S]) Divider() *Rect[S] {
+	if p.divider == nil {
+		return nil
+	}
+	return p.divider.Clone()
+}
+
+// SplitPane lays out a tree of panes separated by draggable dividers.
+type SplitPane[S ng.Scalar] struct {
+	root      *Pane[S]
+	thickness S
+	bounds    *Rect[S]
+}
+
+// NewSplitPane creates a SplitPane that lays out root within bounds, with
+// dividers of the given thickness.
+func NewSplitPane[S ng.Scalar](root *Pane[S], bounds *Rect[S], thickness S) *SplitPane[S] {
+	sp := &SplitPane[S]{
+		root:      root,
+		thickness: thickness,
+	}
+	sp.Resize(bounds)
+	return sp
+}
+
+// Root returns the root pane.
+func (sp *SplitPane[S]) Root() *Pane[S] {
+	return sp.root
+}
+
+// Resize lays out the panes within new bounds.
+func (sp *SplitPane[S]) Resize(bounds *Rect[S]) {
+	sp.bounds = bounds.Clone()
+	sp.layout(sp.root, sp.bounds.Clone())
+}
+
+func (sp *SplitPane[S]) layout(p *Pane[S], r *Rect[S]) {
+	p.rect = r
+	if p.IsLeaf() {
+		p.divider = nil
+		return
+	}
+	size := r.Dx()
+	if p.Vertical {
+		size = r.Dy()
+	}
+	avail := sub0(size, sp.thickness)
+	// Like CutXByRate, but rounded to the nearest so that the ratio stored
+	// by Drag reproduces the dragged size.
+	n := p.clamp(roundTo[S](float64(avail)*p.Ratio, RoundHalfEven), avail)
+
+	side := Left
+	if p.Vertical {
+		side = Top
+	}
+	rest := r.Clone()
+	a := rest.Take(side, n, 0)
+	p.divider = rest.Take(side, sp.thickness, 0)
+	sp.layout(p.A, a)
+	sp.layout(p.B, rest)
+}
+
+// clamp limits the size n of p.A out of avail so that both children satisfy
+// their limits. If they cannot, p.A takes priority.
+func (p *Pane[S]) clamp(n, avail S) S {
+	if p.B.Max > 0 {
+		n = max(n, sub0(avail, p.B.Max))
+	}
+	n = min(n, sub0(avail, p.B.Min))
+	if p.A.Max > 0 {
+		n = min(n, p.A.Max)
+	}
+	n = max(n, p.A.Min)
+	return min(n, avail)
+}
+
+// Dividers returns an iterator over the split panes and their dividers in
+// depth-first order.
+func (sp *SplitPane[S]) Dividers() iter.Seq2[*Pane[S], *Rect[S]] {
+	return func(yield func(*Pane[S], *Rect[S]) bool) {
+		for p := range sp.splits() {
+			if !yield(p, p.divider.Clone()) {
+				return
+			}
+		}
+	}
+}
+
+// DividerAt returns the split pane whose divider contains pt, or nil.
+func (sp *SplitPane[S]) DividerAt(pt Point[S]) *Pane[S] {
+	for p := range sp.splits() {
+		if pt.In(*p.divider) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Drag moves the divider of the split pane p by delta along its axis,
+// respecting the size limits, and lays out the panes again.
+func (sp *SplitPane[S]) Drag(p *Pane[S], delta Point[S]) {
+	if p == nil || p.IsLeaf() || p.rect == nil {
+		return
+	}
+	size, n, d := p.rect.Dx(), p.A.rect.Dx(), delta.X
+	if p.Vertical {
+		size, n, d = p.rect.Dy(), p.A.rect.Dy(), delta.Y
+	}
+	avail := sub0(size, sp.thickness)
+	if avail == 0 {
+		return
+	}
+	n = p.clamp(max(0, min(avail, n+d)), avail)
+	p.Ratio = float64(n) / float64(avail)
+	sp.layout(p, p.rect)
+}
+
+// Ratios returns the ratios of the split panes in depth-first order.
+func (sp *SplitPane[S]) Ratios() []float64 {
+	var rs []float64
+	for p := range sp.splits() {
+		rs = append(rs, p.Ratio)
+	}
+	return rs
+}
+
+// SetRatios restores the ratios returned by [SplitPane.Ratios] and lays out
+// the panes again.
+func (sp *SplitPane[S]) SetRatios(rs []float64) error {
+	var ps []*Pane[S]
+	for p := range sp.splits() {
+		ps = append(ps, p)
+	}
+	if len(ps) != len(rs) {
+		return errors.New("align: ratio count does not match split pane count")
+	}
+	for i, p := range ps {
+		p.Ratio = rs[i]
+	}
+	sp.Resize(sp.bounds)
+	return nil
+}
+
+func (sp *SplitPane[S]) splits() iter.Seq[*Pane[S]] {
+	return func(yield func(*Pane[S]) bool) {
+		var walk func(p *Pane[S]) bool
+		walk = func(p *Pane[S]) bool {
+			if p.IsLeaf() {
+				return true
+			}
+			return yield(p) && walk(p.A) && walk(p.B)
+		}
+		walk(sp.root)
+	}
+}
+
+// sub0 returns a-b, or 0 if b > a.
+func sub0[S ng.Scalar](a, b S) S {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
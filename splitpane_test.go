@@ -0,0 +1,49 @@
+package align
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitPane(t *testing.T) {
+	tree := NewPane[int]().Limit(20, 60)
+	view := NewPane[int]()
+	props := NewPane[int]().Limit(10, 0)
+	inner := SplitV(.5, view, props)
+	root := SplitH(.25, tree, inner)
+	sp := NewSplitPane(root, WH(104, 50), 4)
+
+	check := func(name string, got, want *Rect[int]) {
+		t.Helper()
+		if !got.Eq(want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+	check("tree", tree.Rect(), XYWH(0, 0, 25, 50))
+	check("divider", root.Divider(), XYWH(25, 0, 4, 50))
+	check("view", view.Rect(), XYWH(29, 0, 75, 23))
+	check("props", props.Rect(), XYWH(29, 27, 75, 23))
+
+	if got := sp.DividerAt(XY(27, 10)); got != root {
+		t.Errorf("DividerAt = %p, want root %p", got, root)
+	}
+
+	sp.Drag(root, XY(100, 0))
+	check("tree max", tree.Rect(), XYWH(0, 0, 60, 50))
+	sp.Drag(root, XY(-100, 0))
+	check("tree min", tree.Rect(), XYWH(0, 0, 20, 50))
+	sp.Drag(inner, XY(0, 100))
+	check("props min", props.Rect(), XYWH(24, 40, 80, 10))
+
+	rs := sp.Ratios()
+	if want := []float64{.2, 36.0 / 46}; !slices.Equal(rs, want) {
+		t.Errorf("Ratios() = %v, want %v", rs, want)
+	}
+	if err := sp.SetRatios([]float64{.5, .5}); err != nil {
+		t.Fatal(err)
+	}
+	check("restored", tree.Rect(), XYWH(0, 0, 50, 50))
+	if err := sp.SetRatios([]float64{.5}); err == nil {
+		t.Error("SetRatios with wrong count succeeded")
+	}
+}
@@ -0,0 +1,124 @@
+package align
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// Spring holds the parameters of a damped spring.
+type Spring struct {
+	Stiffness float64 // force per unit of distance from the target
+	Damping   float64 // force per unit of velocity
+	Mass      float64 // treated as 1 if not positive
+}
+
+// DefaultSpring is a responsive spring that settles quickly with little
+// overshoot.
+var DefaultSpring = Spring{Stiffness: 170, Damping: 26, Mass: 1}
+
+const (
+	// springStep is the largest time step the integration takes, for
+	// stability with stiff springs and long frames.
+	springStep = 1.0 / 240
+	// springRest is the distance and speed below which a spring is settled.
+	springRest = 0.01
+)
+
+// springState is the state of the four edges of an animated rectangle in the
+// order Min.X, Min.Y, Max.X, Max.Y.
+type springState struct {
+	pos, vel, target [4]float64
+}
+
+// Springs animates rectangles towards their target positions with damped
+// springs. Each rectangle is identified by a key, such as a node pointer or a
+// name. Compute the layout every frame with the usual align calls, pass the
+// results to Target, advance the animation with Step and draw the rectangles
+// returned by Rect.
+type Springs[K comparable, S ng.Scalar] struct {
+	Spring Spring
+	states map[K]*springState
+}
+
+// NewSprings creates an animator that uses spring for all rectangles.
+func NewSprings[K comparable, S ng.Scalar](spring Spring) *Springs[K, S] {
+	return &Springs[K, S]{Spring: spring, states: map[K]*springState{}}
+}
+
+// Target sets the target rectangle of key. A new key appears at its target
+// without animating.
+func (s *Springs[K, S]) Target(key K, r *Rect[S]) {
+	t := edges(r)
+	st, ok := s.states[key]
+	if !ok {
+		s.states[key] = &springState{pos: t, target: t}
+		return
+	}
+	st.target = t
+}
+
+// Set moves key to r immediately and stops it there.
+func (s *Springs[K, S]) Set(key K, r *Rect[S]) {
+	t := edges(r)
+	s.states[key] = &springState{pos: t, target: t}
+}
+
+// Remove stops animating key.
+func (s *Springs[K, S]) Remove(key K) {
+	delete(s.states, key)
+}
+
+// Rect returns the current rectangle of key, or nil if key has no target.
+// Coordinates are rounded half to even for integer types.
+func (s *Springs[K, S]) Rect(key K) *Rect[S] {
+	st, ok := s.states[key]
+	if !ok {
+		return nil
+	}
+	return XYXY(
+		roundTo[S](st.pos[0], RoundHalfEven),
+		roundTo[S](st.pos[1], RoundHalfEven),
+		roundTo[S](st.pos[2], RoundHalfEven),
+		roundTo[S](st.pos[3], RoundHalfEven),
+	)
+}
+
+// Step advances the animation by dt seconds.
+func (s *Springs[K, S]) Step(dt float64) {
+	m := s.Spring.Mass
+	if m <= 0 {
+		m = 1
+	}
+	n := max(1, int(math.Ceil(dt/springStep)))
+	h := dt / float64(n)
+	for _, st := range s.states {
+		for i := range st.pos {
+			x, v, t := st.pos[i], st.vel[i], st.target[i]
+			for range n {
+				a := (-s.Spring.Stiffness*(x-t) - s.Spring.Damping*v) / m
+				v += a * h
+				x += v * h
+			}
+			if math.Abs(x-t) < springRest && math.Abs(v) < springRest {
+				x, v = t, 0
+			}
+			st.pos[i], st.vel[i] = x, v
+		}
+	}
+}
+
+// Settled reports whether every rectangle has come to rest at its target.
+func (s *Springs[K, S]) Settled() bool {
+	for _, st := range s.states {
+		if st.pos != st.target || st.vel != [4]float64{} {
+			return false
+		}
+	}
+	return true
+}
+
+// edges returns the edges of r in the order of springState.
+func edges[S ng.Scalar](r *Rect[S]) [4]float64 {
+	return [4]float64{float64(r.Min.X), float64(r.Min.Y), float64(r.Max.X), float64(r.Max.Y)}
+}
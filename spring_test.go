@@ -0,0 +1,59 @@
+package align
+
+import "testing"
+
+func TestSprings(t *testing.T) {
+	s := NewSprings[string, int](DefaultSpring)
+	s.Target("panel", XYWH(0, 0, 100, 50))
+	if !s.Settled() {
+		t.Fatal("new key is not settled at its target")
+	}
+	if got, want := s.Rect("panel"), XYWH(0, 0, 100, 50); !got.Eq(want) {
+		t.Errorf("Rect = %v, want %v", got, want)
+	}
+	if s.Rect("missing") != nil {
+		t.Error("Rect of unknown key is not nil")
+	}
+
+	target := XYWH(200, 0, 100, 50)
+	s.Target("panel", target)
+	s.Step(1.0 / 60)
+	if got := s.Rect("panel"); got.Min.X <= 0 || got.Min.X >= 200 || got.Dx() != 100 {
+		t.Errorf("after one frame Rect = %v, want between start and target", got)
+	}
+	if s.Settled() {
+		t.Error("settled after one frame")
+	}
+
+	frames := 0
+	for ; !s.Settled() && frames < 600; frames++ {
+		s.Step(1.0 / 60)
+	}
+	if !s.Settled() {
+		t.Fatal("not settled after 10 seconds")
+	}
+	if got := s.Rect("panel"); !got.Eq(target) {
+		t.Errorf("settled Rect = %v, want %v", got, target)
+	}
+
+	s.Set("panel", XYWH(0, 0, 10, 10))
+	if !s.Settled() {
+		t.Error("not settled after Set")
+	}
+}
+
+func TestSpringsOvershoot(t *testing.T) {
+	s := NewSprings[int, float64](Spring{Stiffness: 300, Damping: 5, Mass: 1})
+	s.Target(0, XYWH(0.0, 0, 10, 10))
+	s.Target(0, XYWH(100.0, 0, 10, 10))
+	overshot := false
+	for range 120 {
+		s.Step(1.0 / 60)
+		if s.Rect(0).Min.X > 100 {
+			overshot = true
+		}
+	}
+	if !overshot {
+		t.Error("underdamped spring did not overshoot")
+	}
+}
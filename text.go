@@ -0,0 +1,349 @@
+package align
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eihigh/ng"
+)
+
+// TextMeasurer measures text set in a single font.
+type TextMeasurer interface {
+	// Advance returns the advance width of s.
+	Advance(s string) float64
+	// RuneAdvance returns the advance width of r.
+	RuneAdvance(r rune) float64
+	// LineHeight returns the distance between the tops of consecutive lines.
+	LineHeight() float64
+	// Ascent returns the distance from the top of a line to its baseline.
+	Ascent() float64
+}
+
+// Monospace is a [TextMeasurer] for fonts whose runes all occupy one cell,
+// such as terminal cells or bitmap fonts.
+type Monospace struct {
+	CellWidth  float64
+	CellHeight float64
+	CellAscent float64
+}
+
+// Advance implements [TextMeasurer] interface.
+func (m Monospace) Advance(s string) float64 {
+	return float64(utf8.RuneCountInString(s)) * m.CellWidth
+}
+
+// RuneAdvance implements [TextMeasurer] interface.
+func (m Monospace) RuneAdvance(r rune) float64 { return m.CellWidth }
+
+// LineHeight implements [TextMeasurer] interface.
+func (m Monospace) LineHeight() float64 { return m.CellHeight }
+
+// Ascent implements [TextMeasurer] interface.
+func (m Monospace) Ascent() float64 { return m.CellAscent }
+
+// TextAlign specifies the horizontal alignment of the lines of a [Paragraph].
+type TextAlign int
+
+const (
+	// TextLeft aligns the lines to the left edge.
+	TextLeft TextAlign = iota
+	// TextCenter centers the lines.
+	TextCenter
+	// TextRight aligns the lines to the right edge.
+	TextRight
+	// TextJustify stretches the spaces so that every line but the last line
+	// of each paragraph fills the width.
+	TextJustify
+)
+
+// LineBreak selects the line breaking algorithm of a [Paragraph].
+type LineBreak int
+
+const (
+	// BreakGreedy puts as many words on each line as fit.
+	BreakGreedy LineBreak = iota
+	// BreakOptimal minimizes the sum of the squared unused widths of all
+	// lines but the last, as in Knuth-Plass without hyphenation, for evenly
+	// filled lines.
+	BreakOptimal
+)
+
+// Paragraph lays out text in lines within a rectangle. Lines are broken at
+// spaces and at newlines; words wider than the rectangle are broken between
+// runes.
+type Paragraph[S ng.Scalar] struct {
+	Measurer TextMeasurer
+	Align    TextAlign
+	Break    LineBreak
+	// MaxLines limits the number of lines if positive. The lines are also
+	// limited to those that fit in the height of the rectangle.
+	MaxLines int
+	// Ellipsis is appended to the last line if the text is truncated, such
+	// as "…". If empty, the text is truncated at a line break.
+	Ellipsis string
+}
+
+// TextBlock is a laid out [Paragraph].
+type TextBlock[S ng.Scalar] struct {
+	Lines []*TextLine[S]
+	// Truncated reports whether some of the text did not fit.
+	Truncated bool
+}
+
+// Bounds implements [Node] interface.
+func (b *TextBlock[S]) Bounds() *Rect[S] {
+	s := make(Slice[S], len(b.Lines))
+	for i, l := range b.Lines {
+		s[i] = l
+	}
+	return s.Bounds()
+}
+
+// Baseline implements [Baseliner] interface. It returns the baseline of the
+// first line, or the bottom of the block if it has no lines.
+func (b *TextBlock[S]) Baseline() S {
+	if len(b.Lines) == 0 {
+		return b.Bounds().Max.Y
+	}
+	return b.Lines[0].Baseline()
+}
+
+// Shift implements [Node] interface.
+func (b *TextBlock[S]) Shift(p Point[S]) {
+	for _, l := range b.Lines {
+		l.Shift(p)
+	}
+}
+
+// TextLine is a line of a [TextBlock].
+type TextLine[S ng.Scalar] struct {
+	Text string
+	// Line spans the text horizontally and the line height vertically.
+	Line *Rect[S]
+	// Ascent is the distance from the top of Line to the baseline.
+	Ascent S
+	// Runs holds the words of the line.
+	Runs []TextRun[S]
+}
+
+// TextRun is a run of glyphs without spaces.
+type TextRun[S ng.Scalar] struct {
+	Text string
+	Rect *Rect[S]
+}
+
+// Bounds implements [Node] interface.
+func (l *TextLine[S]) Bounds() *Rect[S] {
+	return l.Line
+}
+
+// Baseline implements [Baseliner] interface.
+func (l *TextLine[S]) Baseline() S {
+	return l.Line.Min.Y + l.Ascent
+}
+
+// Shift implements [Node] interface.
+func (l *TextLine[S]) Shift(p Point[S]) {
+	l.Line.Shift(p)
+	for _, r := range l.Runs {
+		r.Rect.Shift(p)
+	}
+}
+
+// textLine is a line of words before positioning.
+type textLine struct {
+	words []string
+	last  bool // the last line of a paragraph
+}
+
+// Layout breaks text into lines within bounds, starting at its top.
+func (p Paragraph[S]) Layout(text string, bounds *Rect[S]) *TextBlock[S] {
+	m := p.Measurer
+	width := float64(bounds.Dx())
+	var lines []textLine
+	for para := range strings.SplitSeq(text, "\n") {
+		words := p.words(para, width)
+		var breaks []int
+		if p.Break == BreakOptimal {
+			breaks = p.breakOptimal(words, width)
+		} else {
+			breaks = p.breakGreedy(words, width)
+		}
+		i := 0
+		for _, j := range breaks {
+			lines = append(lines, textLine{words: words[i:j]})
+			i = j
+		}
+		lines[len(lines)-1].last = true
+	}
+
+	block := &TextBlock[S]{}
+	lh := m.LineHeight()
+	n := len(lines)
+	if lh > 0 {
+		n = min(n, int(float64(bounds.Dy())/lh))
+	}
+	if p.MaxLines > 0 {
+		n = min(n, p.MaxLines)
+	}
+	if n < len(lines) {
+		block.Truncated = true
+		lines = lines[:n]
+		if n > 0 && p.Ellipsis != "" {
+			s := p.truncate(strings.Join(lines[n-1].words, " "), width)
+			lines[n-1] = textLine{words: strings.Fields(s), last: true}
+		}
+	}
+
+	for i, tl := range lines {
+		top := bounds.Min.Y + roundTo[S](float64(i)*lh, RoundHalfEven)
+		bottom := bounds.Min.Y + roundTo[S](float64(i+1)*lh, RoundHalfEven)
+		block.Lines = append(block.Lines, p.position(tl, bounds, top, bottom))
+	}
+	return block
+}
+
+// words splits para into words, breaking words wider than width between
+// runes.
+func (p Paragraph[S]) words(para string, width float64) []string {
+	var words []string
+	for w := range strings.FieldsSeq(para) {
+		for p.Measurer.Advance(w) > width {
+			i, adv := 0, 0.0
+			for j, r := range w {
+				adv += p.Measurer.RuneAdvance(r)
+				if adv > width && j > 0 {
+					break
+				}
+				i = j + utf8.RuneLen(r)
+			}
+			if i >= len(w) {
+				break
+			}
+			words = append(words, w[:i])
+			w = w[i:]
+		}
+		words = append(words, w)
+	}
+	return words
+}
+
+// breakGreedy returns the end index of each line of words.
+func (p Paragraph[S]) breakGreedy(words []string, width float64) []int {
+	if len(words) == 0 {
+		return []int{0}
+	}
+	space := p.Measurer.RuneAdvance(' ')
+	var breaks []int
+	w := 0.0
+	for i, word := range words {
+		adv := p.Measurer.Advance(word)
+		if i > 0 && w+space+adv > width {
+			breaks = append(breaks, i)
+			w = adv
+			continue
+		}
+		if i > 0 {
+			w += space
+		}
+		w += adv
+	}
+	return append(breaks, len(words))
+}
+
+// breakOptimal returns the end index of each line of words.
+func (p Paragraph[S]) breakOptimal(words []string, width float64) []int {
+	n := len(words)
+	if n == 0 {
+		return []int{0}
+	}
+	space := p.Measurer.RuneAdvance(' ')
+	advs := make([]float64, n)
+	for i, w := range words {
+		advs[i] = p.Measurer.Advance(w)
+	}
+	// cost[j] is the least cost of breaking words[:j]; from[j] is the start
+	// of the last line of that breaking.
+	cost := make([]float64, n+1)
+	from := make([]int, n+1)
+	for j := 1; j <= n; j++ {
+		cost[j] = -1
+		w := -space
+		for i := j - 1; i >= 0; i-- {
+			w += space + advs[i]
+			if w > width && i < j-1 {
+				break
+			}
+			c := cost[i]
+			if j < n {
+				c += (width - w) * (width - w)
+			}
+			if cost[j] < 0 || c < cost[j] {
+				cost[j], from[j] = c, i
+			}
+		}
+	}
+	var breaks []int
+	for j := n; j > 0; j = from[j] {
+		breaks = append(breaks, j)
+	}
+	for i, j := 0, len(breaks)-1; i < j; i, j = i+1, j-1 {
+		breaks[i], breaks[j] = breaks[j], breaks[i]
+	}
+	return breaks
+}
+
+// truncate shortens s so that it fits in width with the ellipsis appended.
+func (p Paragraph[S]) truncate(s string, width float64) string {
+	for s != "" && p.Measurer.Advance(s+p.Ellipsis) > width {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+	return strings.TrimRight(s, " ") + p.Ellipsis
+}
+
+// position places the words of tl in the line from top to bottom of bounds.
+func (p Paragraph[S]) position(tl textLine, bounds *Rect[S], top, bottom S) *TextLine[S] {
+	m := p.Measurer
+	width := float64(bounds.Dx())
+	space := m.RuneAdvance(' ')
+	advs := make([]float64, len(tl.words))
+	w := 0.0
+	for i, word := range tl.words {
+		advs[i] = m.Advance(word)
+		w += advs[i]
+	}
+	gap := space
+	if len(advs) > 1 {
+		w += space * float64(len(advs)-1)
+		if p.Align == TextJustify && !tl.last {
+			gap += (width - w) / float64(len(advs)-1)
+			w = width
+		}
+	}
+
+	x := 0.0
+	switch p.Align {
+	case TextCenter:
+		x = (width - w) / 2
+	case TextRight:
+		x = width - w
+	}
+	x0 := bounds.Min.X + roundTo[S](x, RoundHalfEven)
+	l := &TextLine[S]{
+		Text:   strings.Join(tl.words, " "),
+		Line:   XYXY(x0, top, bounds.Min.X+roundTo[S](x+w, RoundHalfEven), bottom),
+		Ascent: roundTo[S](m.Ascent(), RoundHalfEven),
+	}
+	for i, word := range tl.words {
+		l.Runs = append(l.Runs, TextRun[S]{
+			Text: word,
+			Rect: XYXY(
+				bounds.Min.X+roundTo[S](x, RoundHalfEven), top,
+				bounds.Min.X+roundTo[S](x+advs[i], RoundHalfEven), bottom,
+			),
+		})
+		x += advs[i] + gap
+	}
+	return l
+}
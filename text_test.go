@@ -0,0 +1,133 @@
+package align
+
+import (
+	"slices"
+	"testing"
+)
+
+func lineTexts(b *TextBlock[int]) []string {
+	var s []string
+	for _, l := range b.Lines {
+		s = append(s, l.Text)
+	}
+	return s
+}
+
+func TestParagraph(t *testing.T) {
+	mono := Monospace{CellWidth: 10, CellHeight: 20, CellAscent: 15}
+	tests := []struct {
+		name      string
+		p         Paragraph[int]
+		text      string
+		bounds    *Rect[int]
+		want      []string
+		truncated bool
+	}{
+		{
+			name:   "greedy",
+			p:      Paragraph[int]{Measurer: mono},
+			text:   "the quick brown fox jumps",
+			bounds: WH(100, 100),
+			want:   []string{"the quick", "brown fox", "jumps"},
+		},
+		{
+			name:   "newlines",
+			p:      Paragraph[int]{Measurer: mono},
+			text:   "one\n\ntwo  three",
+			bounds: WH(100, 100),
+			want:   []string{"one", "", "two three"},
+		},
+		{
+			name:   "optimal",
+			p:      Paragraph[int]{Measurer: mono, Break: BreakOptimal},
+			text:   "aaa bb cc ddddd",
+			bounds: WH(60, 100),
+			want:   []string{"aaa", "bb cc", "ddddd"},
+		},
+		{
+			name:   "greedy for comparison",
+			p:      Paragraph[int]{Measurer: mono},
+			text:   "aaa bb cc ddddd",
+			bounds: WH(60, 100),
+			want:   []string{"aaa bb", "cc", "ddddd"},
+		},
+		{
+			name:   "long word",
+			p:      Paragraph[int]{Measurer: mono},
+			text:   "abcdefghijkl",
+			bounds: WH(50, 100),
+			want:   []string{"abcde", "fghij", "kl"},
+		},
+		{
+			name:      "ellipsis",
+			p:         Paragraph[int]{Measurer: mono, Ellipsis: "…"},
+			text:      "the quick brown fox jumps",
+			bounds:    WH(100, 40),
+			want:      []string{"the quick", "brown fox…"},
+			truncated: true,
+		},
+		{
+			name:      "ellipsis shortens the line",
+			p:         Paragraph[int]{Measurer: mono, MaxLines: 1, Ellipsis: "..."},
+			text:      "the quick brown fox jumps",
+			bounds:    WH(100, 100),
+			want:      []string{"the qui..."},
+			truncated: true,
+		},
+		{
+			name:      "no ellipsis",
+			p:         Paragraph[int]{Measurer: mono, MaxLines: 1},
+			text:      "the quick brown fox jumps",
+			bounds:    WH(100, 100),
+			want:      []string{"the quick"},
+			truncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.p.Layout(tt.text, tt.bounds)
+			if got := lineTexts(b); !slices.Equal(got, tt.want) {
+				t.Errorf("lines = %q, want %q", got, tt.want)
+			}
+			if b.Truncated != tt.truncated {
+				t.Errorf("Truncated = %v, want %v", b.Truncated, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestParagraphAlign(t *testing.T) {
+	mono := Monospace{CellWidth: 10, CellHeight: 20, CellAscent: 15}
+	text := "the quick brown fox jumps"
+	bounds := XYWH(10, 10, 100, 100)
+
+	center := Paragraph[int]{Measurer: mono, Align: TextCenter}.Layout(text, bounds)
+	right := Paragraph[int]{Measurer: mono, Align: TextRight}.Layout(text, bounds)
+	justify := Paragraph[int]{Measurer: mono, Align: TextJustify}.Layout(text, bounds)
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "center line", got: center.Lines[2].Line, want: XYXY(35, 50, 85, 70)},
+		{name: "right line", got: right.Lines[0].Line, want: XYXY(20, 10, 110, 30)},
+		{name: "justify first run", got: justify.Lines[0].Runs[0].Rect, want: XYXY(10, 10, 40, 30)},
+		{name: "justify second run", got: justify.Lines[0].Runs[1].Rect, want: XYXY(60, 10, 110, 30)},
+		{name: "justify last line", got: justify.Lines[2].Line, want: XYXY(10, 50, 60, 70)},
+		{name: "block", got: center.Bounds(), want: XYXY(15, 10, 105, 70)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+	if got := center.Lines[1].Baseline(); got != 45 {
+		t.Errorf("Baseline = %d, want 45", got)
+	}
+
+	center.Shift(XY(0, 5))
+	if got := center.Lines[1].Baseline(); got != 50 {
+		t.Errorf("Baseline after Shift = %d, want 50", got)
+	}
+}
@@ -0,0 +1,328 @@
+package align
+
+import (
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// TilePreset selects how a [Tiler] arranges its windows.
+type TilePreset int
+
+const (
+	// TileBSP splits the focused tile along its longer axis whenever a
+	// window is inserted.
+	TileBSP TilePreset = iota
+	// TileMasterStack places the first window on the left and stacks the
+	// others vertically on the right.
+	TileMasterStack
+	// TileSpiral splits the remaining space in half for each window,
+	// turning clockwise in a Fibonacci spiral.
+	TileSpiral
+)
+
+// Tiler is a tiling window layout based on binary space partitioning.
+// Windows are identified by string ids.
+type Tiler[S ng.Scalar] struct {
+	bounds *Rect[S]
+	gap    S
+	preset TilePreset
+	root   *tile
+	focus  string
+	order  []string
+}
+
+// A tile is a node of the partition tree: a window if a and b are nil, or a
+// split otherwise.
+type tile struct {
+	id       string
+	parent   *tile
+	a, b     *tile
+	vertical bool
+	ratio    float64
+}
+
+// NewTiler creates a Tiler within bounds with gap spacing between tiles.
+func NewTiler[S ng.Scalar](bounds *Rect[S], gap S) *Tiler[S] {
+	return &Tiler[S]{
+		bounds: bounds.Clone(),
+		gap:    gap,
+	}
+}
+
+// Resize changes the bounds of t.
+func (t *Tiler[S]) Resize(bounds *Rect[S]) {
+	t.bounds = bounds.Clone()
+}
+
+// Preset returns the current preset.
+func (t *Tiler[S]) Preset() TilePreset {
+	return t.preset
+}
+
+// SetPreset rearranges the windows, in insertion order, with preset p.
+func (t *Tiler[S]) SetPreset(p TilePreset) {
+	t.preset = p
+	if p == TileBSP {
+		return // keep the current tree
+	}
+	t.rebuild()
+}
+
+// Windows returns the window ids in insertion order.
+func (t *Tiler[S]) Windows() []string {
+	return slices.Clone(t.order)
+}
+
+// Focused returns the id of the focused window.
+func (t *Tiler[S]) Focused() string {
+	return t.focus
+}
+
+// Focus focuses the window id. It reports whether the window exists.
+func (t *Tiler[S]) Focus(id string) bool {
+	if t.find(id) == nil {
+		return false
+	}
+	t.focus = id
+	return true
+}
+
+// Insert adds the window id and focuses it. With TileBSP, the focused tile is
+// split in half along its longer axis. It reports false if id already exists.
+func (t *Tiler[S]) Insert(id string) bool {
+	if t.find(id) != nil {
+		return false
+	}
+	t.order = append(t.order, id)
+	defer func() { t.focus = id }()
+
+	if t.preset != TileBSP {
+		t.rebuild()
+		return true
+	}
+	n := &tile{id: id}
+	old := t.find(t.focus)
+	if old == nil {
+		old = t.last()
+	}
+	if old == nil {
+		t.root = n
+		return true
+	}
+	r := t.rects()[old]
+	split := &tile{
+		parent:   old.parent,
+		a:        old,
+		b:        n,
+		vertical: r.Dy() > r.Dx(),
+		ratio:    0.5,
+	}
+	t.replace(old, split)
+	old.parent, n.parent = split, split
+	return true
+}
+
+// Remove removes the window id and rebalances the remaining tiles. It
+// reports whether the window existed.
+func (t *Tiler[S]) Remove(id string) bool {
+	n := t.find(id)
+	if n == nil {
+		return false
+	}
+	t.order = slices.DeleteFunc(t.order, func(s string) bool { return s == id })
+	if t.preset != TileBSP {
+		t.rebuild()
+	} else if p := n.parent; p == nil {
+		t.root = nil
+	} else {
+		sib := p.a
+		if sib == n {
+			sib = p.b
+		}
+		t.replace(p, sib)
+		t.Balance()
+	}
+	if t.focus == id {
+		t.focus = ""
+		if l := t.last(); l != nil {
+			t.focus = l.id
+		}
+	}
+	return true
+}
+
+// Swap exchanges the tiles of the windows a and b. It reports whether both
+// windows exist.
+func (t *Tiler[S]) Swap(a, b string) bool {
+	ta, tb := t.find(a), t.find(b)
+	if ta == nil || tb == nil {
+		return false
+	}
+	ta.id, tb.id = tb.id, ta.id
+	ia, ib := slices.Index(t.order, a), slices.Index(t.order, b)
+	t.order[ia], t.order[ib] = t.order[ib], t.order[ia]
+	return true
+}
+
+// Rotate flips the orientation of the split containing the window id, and
+// swaps its sides every other rotation so that repeated calls turn the
+// split clockwise. It reports whether the window is in a split.
+func (t *Tiler[S]) Rotate(id string) bool {
+	n := t.find(id)
+	if n == nil || n.parent == nil {
+		return false
+	}
+	p := n.parent
+	if p.vertical {
+		p.a, p.b = p.b, p.a
+		p.ratio = 1 - p.ratio
+	}
+	p.vertical = !p.vertical
+	return true
+}
+
+// Balance resets the split ratios so that the space is divided evenly among
+// the windows in each subtree.
+func (t *Tiler[S]) Balance() {
+	var count func(n *tile) int
+	count = func(n *tile) int {
+		if n == nil {
+			return 0
+		}
+		if n.a == nil {
+			return 1
+		}
+		ca, cb := count(n.a), count(n.b)
+		n.ratio = float64(ca) / float64(ca+cb)
+		return ca + cb
+	}
+	count(t.root)
+}
+
+// Layout returns the tile of each window keyed by id.
+func (t *Tiler[S]) Layout() Map[S] {
+	m := Map[S]{}
+	for n, r := range t.rects() {
+		if n.a == nil {
+			m[n.id] = r
+		}
+	}
+	return m
+}
+
+// rects returns the rectangle of every tile.
+func (t *Tiler[S]) rects() map[*tile]*Rect[S] {
+	m := map[*tile]*Rect[S]{}
+	var walk func(n *tile, r *Rect[S])
+	walk = func(n *tile, r *Rect[S]) {
+		m[n] = r
+		if n.a == nil {
+			return
+		}
+		// The ratio divides the space left after the gap.
+		var a, b *Rect[S]
+		if n.vertical {
+			a, b = r.CutY(S(float64(max(0, r.Dy()-t.gap)) * n.ratio))
+			_, b = b.CutY(t.gap)
+		} else {
+			a, b = r.CutX(S(float64(max(0, r.Dx()-t.gap)) * n.ratio))
+			_, b = b.CutX(t.gap)
+		}
+		walk(n.a, a)
+		walk(n.b, b)
+	}
+	if t.root != nil {
+		walk(t.root, t.bounds.Clone())
+	}
+	return m
+}
+
+// rebuild recreates the tree from the window order according to a preset
+// other than TileBSP.
+func (t *Tiler[S]) rebuild() {
+	t.root = nil
+	n := len(t.order)
+	if n == 0 {
+		return
+	}
+	leaves := make([]*tile, n)
+	for i, id := range t.order {
+		leaves[i] = &tile{id: id}
+	}
+
+	switch t.preset {
+	case TileMasterStack:
+		// Chain the stack from the bottom up with equal heights.
+		stack := leaves[n-1]
+		for i := n - 2; i >= 1; i-- {
+			stack = join(leaves[i], stack, true, 1/float64(n-i))
+		}
+		if n == 1 {
+			t.root = stack
+		} else {
+			t.root = join(leaves[0], stack, false, 0.5)
+		}
+	case TileSpiral:
+		// Turn right, down, left, up: windows 0 and 1 of every four take the
+		// first half, windows 2 and 3 the second.
+		rest := leaves[n-1]
+		for i := n - 2; i >= 0; i-- {
+			vertical := i%2 == 1
+			if i%4 < 2 {
+				rest = join(leaves[i], rest, vertical, 0.5)
+			} else {
+				rest = join(rest, leaves[i], vertical, 0.5)
+			}
+		}
+		t.root = rest
+	}
+}
+
+func join(a, b *tile, vertical bool, ratio float64) *tile {
+	n := &tile{a: a, b: b, vertical: vertical, ratio: ratio}
+	a.parent, b.parent = n, n
+	return n
+}
+
+// replace puts n in the place of old in the tree.
+func (t *Tiler[S]) replace(old, n *tile) {
+	p := old.parent
+	n.parent = p
+	switch {
+	case p == nil:
+		t.root = n
+	case p.a == old:
+		p.a = n
+	default:
+		p.b = n
+	}
+}
+
+func (t *Tiler[S]) find(id string) *tile {
+	var walk func(n *tile) *tile
+	walk = func(n *tile) *tile {
+		if n == nil {
+			return nil
+		}
+		if n.a == nil {
+			if n.id == id {
+				return n
+			}
+			return nil
+		}
+		if f := walk(n.a); f != nil {
+			return f
+		}
+		return walk(n.b)
+	}
+	return walk(t.root)
+}
+
+// last returns the window tile inserted last, or nil.
+func (t *Tiler[S]) last() *tile {
+	if len(t.order) == 0 {
+		return nil
+	}
+	return t.find(t.order[len(t.order)-1])
+}
@@ -0,0 +1,79 @@
+package align
+
+import "testing"
+
+func TestTiler(t *testing.T) {
+	tl := NewTiler(WH(100, 60), 0)
+	for _, id := range []string{"a", "b", "c"} {
+		tl.Insert(id)
+	}
+	check := func(name string, m Map[int], want map[string]*Rect[int]) {
+		t.Helper()
+		if len(m) != len(want) {
+			t.Errorf("%s: got %d tiles, want %d", name, len(m), len(want))
+		}
+		for id, w := range want {
+			if got, ok := m[id]; !ok || !got.Bounds().Eq(w) {
+				t.Errorf("%s: %s = %v, want %v", name, id, got, w)
+			}
+		}
+	}
+
+	check("BSP", tl.Layout(), map[string]*Rect[int]{
+		"a": XYWH(0, 0, 50, 60),
+		"b": XYWH(50, 0, 50, 30),
+		"c": XYWH(50, 30, 50, 30),
+	})
+
+	tl.Swap("a", "c")
+	tl.Rotate("a")
+	check("Swap Rotate", tl.Layout(), map[string]*Rect[int]{
+		"c": XYWH(0, 0, 50, 60),
+		"a": XYWH(50, 0, 25, 60),
+		"b": XYWH(75, 0, 25, 60),
+	})
+
+	tl.Remove("c")
+	check("Remove", tl.Layout(), map[string]*Rect[int]{
+		"a": XYWH(0, 0, 50, 60),
+		"b": XYWH(50, 0, 50, 60),
+	})
+
+	tl.Insert("d") // windows are now b, a, d
+	tl.SetPreset(TileMasterStack)
+	check("MasterStack", tl.Layout(), map[string]*Rect[int]{
+		"a": XYWH(50, 0, 50, 30),
+		"b": XYWH(0, 0, 50, 60),
+		"d": XYWH(50, 30, 50, 30),
+	})
+
+	tl.Insert("e")
+	tl.SetPreset(TileSpiral)
+	check("Spiral", tl.Layout(), map[string]*Rect[int]{
+		"a": XYWH(50, 0, 50, 30),
+		"b": XYWH(0, 0, 50, 60),
+		"d": XYWH(75, 30, 25, 30),
+		"e": XYWH(50, 30, 25, 30),
+	})
+	if got := tl.Focused(); got != "e" {
+		t.Errorf("Focused() = %q, want %q", got, "e")
+	}
+}
+
+func TestTilerGap(t *testing.T) {
+	tl := NewTiler(WH(100, 100), 10)
+	for _, id := range []string{"a", "b", "c"} {
+		tl.Insert(id)
+	}
+	m := tl.Layout()
+	want := map[string]*Rect[int]{
+		"a": XYWH(0, 0, 45, 100),
+		"b": XYWH(55, 0, 45, 45),
+		"c": XYWH(55, 55, 45, 45),
+	}
+	for id, w := range want {
+		if got := m[id].Bounds(); !got.Eq(w) {
+			t.Errorf("%s = %v, want %v", id, got, w)
+		}
+	}
+}
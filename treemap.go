@@ -0,0 +1,305 @@
+package align
+
+import (
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// A TreemapItem is a node of weighted hierarchical data. The weight of an
+// item with children is the sum of their weights.
+type TreemapItem struct {
+	Weight   float64
+	Children []TreemapItem
+}
+
+// Total returns the weight of it.
+func (it TreemapItem) Total() float64 {
+	if len(it.Children) == 0 {
+		return max(0, it.Weight)
+	}
+	sum := 0.0
+	for _, c := range it.Children {
+		sum += c.Total()
+	}
+	return sum
+}
+
+// TreemapAlgorithm selects how a [Treemap] divides a cell among its children.
+type TreemapAlgorithm int
+
+const (
+	// Squarified keeps cells close to square, ignoring the input order.
+	Squarified TreemapAlgorithm = iota
+	// SliceAndDice divides each level into strips, alternating between
+	// columns and rows at every level. It keeps the input order.
+	SliceAndDice
+	// Strip packs cells into horizontal strips, keeping the input order while
+	// keeping cells reasonably square.
+	Strip
+)
+
+// Treemap lays out weighted hierarchical data as nested rectangles.
+// Padding and Header are indexed by depth, starting at 0 for the root, and
+// the last value applies to deeper levels.
+type Treemap[S ng.Scalar] struct {
+	Algorithm TreemapAlgorithm
+	// Padding is the inset between a group's cell and its children.
+	Padding []S
+	// Header is the height reserved at the top of a group's cell.
+	Header []S
+}
+
+// TreemapGroup is the cell of a [TreemapItem] with children.
+type TreemapGroup[S ng.Scalar] struct {
+	// Cell is the whole cell of the group.
+	Cell *Rect[S]
+	// Header is the space reserved at the top of the cell.
+	Header *Rect[S]
+	// Children holds the cells of the children in input order: a [*Rect]
+	// for a leaf and a [*TreemapGroup] for an item with children.
+	Children Slice[S]
+}
+
+// Bounds implements [Node] interface.
+func (g *TreemapGroup[S]) Bounds() *Rect[S] {
+	return g.Cell
+}
+
+// Shift implements [Node] interface.
+func (g *TreemapGroup[S]) Shift(p Point[S]) {
+	g.Cell.Shift(p)
+	g.Header.Shift(p)
+	g.Children.Shift(p)
+}
+
+// Layout lays out root within bounds and returns the group of the root.
+func (t Treemap[S]) Layout(root TreemapItem, bounds *Rect[S]) *TreemapGroup[S] {
+	return t.group(root, bounds.Clone(), 0)
+}
+
+func (t Treemap[S]) group(it TreemapItem, cell *Rect[S], depth int) *TreemapGroup[S] {
+	header, content := cell.CutY(level(t.Header, depth))
+	content = content.Inset(level(t.Padding, depth))
+
+	weights := make([]float64, len(it.Children))
+	for i, c := range it.Children {
+		weights[i] = c.Total()
+	}
+	area := frect{
+		float64(content.Min.X), float64(content.Min.Y),
+		float64(content.Dx()), float64(content.Dy()),
+	}
+	var fs []frect
+	switch t.Algorithm {
+	case SliceAndDice:
+		fs = sliceAndDice(weights, area, depth%2 == 1)
+	case Strip:
+		fs = strip(weights, area)
+	default:
+		fs = squarify(weights, area)
+	}
+
+	g := &TreemapGroup[S]{
+		Cell:     cell,
+		Header:   header,
+		Children: make(Slice[S], len(it.Children)),
+	}
+	for i, c := range it.Children {
+		r := fs[i].rect()
+		if len(c.Children) > 0 {
+			g.Children[i] = t.group(c, toRect[S](r), depth+1)
+		} else {
+			g.Children[i] = toRect[S](r)
+		}
+	}
+	return g
+}
+
+// level returns vs[depth], or the last value of vs for deeper levels.
+func level[S ng.Scalar](vs []S, depth int) S {
+	if len(vs) == 0 {
+		return 0
+	}
+	return vs[min(depth, len(vs)-1)]
+}
+
+// frect is a rectangle in float64 used while computing cell areas.
+type frect struct {
+	x, y, w, h float64
+}
+
+func (f frect) rect() *Rect[float64] {
+	return &Rect[float64]{Point[float64]{f.x, f.y}, Point[float64]{f.x + f.w, f.y + f.h}}
+}
+
+// toRect converts r to Rect[S], rounding every edge to the nearest so that
+// adjacent cells stay adjacent.
+func toRect[S ng.Scalar](r *Rect[float64]) *Rect[S] {
+	m := RoundHalfEven
+	return XYXY(
+		roundTo[S](r.Min.X, m), roundTo[S](r.Min.Y, m),
+		roundTo[S](r.Max.X, m), roundTo[S](r.Max.Y, m),
+	)
+}
+
+// areas scales weights so that they sum up to the area of r.
+func areas(weights []float64, r frect) []float64 {
+	sum := 0.0
+	for _, w := range weights {
+		sum += max(0, w)
+	}
+	as := make([]float64, len(weights))
+	if sum == 0 {
+		return as
+	}
+	for i, w := range weights {
+		as[i] = max(0, w) / sum * r.w * r.h
+	}
+	return as
+}
+
+func sliceAndDice(weights []float64, r frect, vertical bool) []frect {
+	as := areas(weights, r)
+	fs := make([]frect, len(as))
+	for i, a := range as {
+		if vertical {
+			h := safeDiv(a, r.w)
+			fs[i] = frect{r.x, r.y, r.w, h}
+			r.y += h
+		} else {
+			w := safeDiv(a, r.h)
+			fs[i] = frect{r.x, r.y, w, r.h}
+			r.x += w
+		}
+	}
+	return fs
+}
+
+func squarify(weights []float64, r frect) []frect {
+	as := areas(weights, r)
+	fs := make([]frect, len(as))
+	var idx []int
+	for i, a := range as {
+		if a > 0 {
+			idx = append(idx, i)
+		} else {
+			fs[i] = frect{r.x, r.y, 0, 0}
+		}
+	}
+	slices.SortStableFunc(idx, func(i, j int) int {
+		switch {
+		case as[i] > as[j]:
+			return -1
+		case as[i] < as[j]:
+			return 1
+		}
+		return 0
+	})
+
+	var row []int
+	for len(idx) > 0 {
+		i := idx[0]
+		side := min(r.w, r.h)
+		if len(row) == 0 || worst(as, append(row, i), side) <= worst(as, row, side) {
+			row = append(row, i)
+			idx = idx[1:]
+			continue
+		}
+		r = layoutRow(as, row, r, fs)
+		row = nil
+	}
+	if len(row) > 0 {
+		layoutRow(as, row, r, fs)
+	}
+	return fs
+}
+
+// worst returns the highest aspect ratio among the cells of row laid out
+// along a side of the given length.
+func worst(as []float64, row []int, side float64) float64 {
+	sum, lo, hi := 0.0, math.Inf(1), 0.0
+	for _, i := range row {
+		sum += as[i]
+		lo = min(lo, as[i])
+		hi = max(hi, as[i])
+	}
+	s2, sum2 := side*side, sum*sum
+	return max(safeDiv(s2*hi, sum2), safeDiv(sum2, s2*lo))
+}
+
+// layoutRow places row along the shorter side of r and returns the rest of r.
+func layoutRow(as []float64, row []int, r frect, fs []frect) frect {
+	sum := 0.0
+	for _, i := range row {
+		sum += as[i]
+	}
+	if r.w >= r.h {
+		w := safeDiv(sum, r.h)
+		y := r.y
+		for _, i := range row {
+			h := safeDiv(as[i], w)
+			fs[i] = frect{r.x, y, w, h}
+			y += h
+		}
+		return frect{r.x + w, r.y, r.w - w, r.h}
+	}
+	h := safeDiv(sum, r.w)
+	x := r.x
+	for _, i := range row {
+		w := safeDiv(as[i], h)
+		fs[i] = frect{x, r.y, w, h}
+		x += w
+	}
+	return frect{r.x, r.y + h, r.w, r.h - h}
+}
+
+func strip(weights []float64, r frect) []frect {
+	as := areas(weights, r)
+	fs := make([]frect, len(as))
+
+	// avg returns the average aspect ratio of as[i:j] in a strip of width r.w.
+	avg := func(i, j int) float64 {
+		sum := 0.0
+		for _, a := range as[i:j] {
+			sum += a
+		}
+		h := safeDiv(sum, r.w)
+		total := 0.0
+		for _, a := range as[i:j] {
+			w := safeDiv(a, h)
+			total += max(safeDiv(w, h), safeDiv(h, w))
+		}
+		return total / float64(j-i)
+	}
+
+	y := r.y
+	for i := 0; i < len(as); {
+		j := i + 1
+		for j < len(as) && avg(i, j+1) <= avg(i, j) {
+			j++
+		}
+		sum := 0.0
+		for _, a := range as[i:j] {
+			sum += a
+		}
+		h := safeDiv(sum, r.w)
+		x := r.x
+		for k := i; k < j; k++ {
+			w := safeDiv(as[k], h)
+			fs[k] = frect{x, y, w, h}
+			x += w
+		}
+		y += h
+		i = j
+	}
+	return fs
+}
+
+func safeDiv(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
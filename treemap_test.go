@@ -0,0 +1,75 @@
+package align
+
+import "testing"
+
+func TestTreemap(t *testing.T) {
+	data := TreemapItem{Children: []TreemapItem{
+		{Weight: 6},
+		{Weight: 6},
+		{Weight: 4},
+		{Weight: 3},
+		{Weight: 2},
+		{Weight: 2},
+		{Weight: 1},
+	}}
+	bounds := WH(6, 4)
+
+	t.Run("Squarified", func(t *testing.T) {
+		// The example from Bruls, Huizing and van Wijk.
+		g := Treemap[float64]{}.Layout(data, WH(6.0, 4.0))
+		want := []*Rect[float64]{
+			XYWH(0.0, 0, 3, 2),
+			XYWH(0.0, 2, 3, 2),
+			XYWH(3, 0, 12.0/7, 4.0*7/12),
+		}
+		for i, w := range want {
+			if got := g.Children[i].Bounds(); !approxEq(got, w) {
+				t.Errorf("child %d = %v, want %v", i, got, w)
+			}
+		}
+	})
+
+	for _, algo := range []TreemapAlgorithm{Squarified, SliceAndDice, Strip} {
+		g := Treemap[int]{Algorithm: algo}.Layout(data, bounds)
+		area := 0
+		for i, c := range g.Children {
+			area += c.Bounds().Dx() * c.Bounds().Dy()
+			if !c.Bounds().In(bounds) {
+				t.Errorf("algorithm %d: child %d = %v is out of bounds", algo, i, c.Bounds())
+			}
+			for _, d := range g.Children[i+1:] {
+				if c.Bounds().Overlaps(d.Bounds()) {
+					t.Errorf("algorithm %d: %v overlaps %v", algo, c.Bounds(), d.Bounds())
+				}
+			}
+		}
+		if area != 24 {
+			t.Errorf("algorithm %d: total area = %d, want 24", algo, area)
+		}
+	}
+
+	t.Run("Nested", func(t *testing.T) {
+		nested := TreemapItem{Children: []TreemapItem{
+			{Weight: 1},
+			{Children: []TreemapItem{{Weight: 1}, {Weight: 1}}},
+		}}
+		tm := Treemap[int]{Algorithm: SliceAndDice, Padding: []int{0, 1}, Header: []int{0, 2}}
+		g := tm.Layout(nested, WH(30, 10))
+		sub, ok := g.Children[1].(*TreemapGroup[int])
+		if !ok {
+			t.Fatalf("child 1 is %T, want *TreemapGroup", g.Children[1])
+		}
+		if want := XYWH(10, 0, 20, 2); !sub.Header.Eq(want) {
+			t.Errorf("header = %v, want %v", sub.Header, want)
+		}
+		if want := XYWH(11, 3, 18, 3); !sub.Children[0].Bounds().Eq(want) {
+			t.Errorf("grandchild = %v, want %v", sub.Children[0].Bounds(), want)
+		}
+	})
+}
+
+func approxEq(a, b *Rect[float64]) bool {
+	const eps = 1e-9
+	d := func(x, y float64) bool { return x-y < eps && y-x < eps }
+	return d(a.Min.X, b.Min.X) && d(a.Min.Y, b.Min.Y) && d(a.Max.X, b.Max.X) && d(a.Max.Y, b.Max.Y)
+}
@@ -0,0 +1,184 @@
+package align
+
+import (
+	"math"
+	"slices"
+
+	"github.com/eihigh/ng"
+)
+
+// An Ease maps the linear progress t in [0, 1] to the eased progress.
+type Ease func(t float64) float64
+
+// EaseLinear does not ease.
+func EaseLinear(t float64) float64 { return t }
+
+// EaseInQuad accelerates from zero velocity.
+func EaseInQuad(t float64) float64 { return t * t }
+
+// EaseOutQuad decelerates to zero velocity.
+func EaseOutQuad(t float64) float64 { return 1 - (1-t)*(1-t) }
+
+// EaseInOutQuad accelerates until halfway, then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - 2*(1-t)*(1-t)
+}
+
+// EaseInCubic accelerates from zero velocity.
+func EaseInCubic(t float64) float64 { return t * t * t }
+
+// EaseOutCubic decelerates to zero velocity.
+func EaseOutCubic(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+
+// EaseInOutCubic accelerates until halfway, then decelerates.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - 4*math.Pow(1-t, 3)
+}
+
+const backC1 = 1.70158
+
+// EaseInBack pulls back slightly before moving.
+func EaseInBack(t float64) float64 { return (backC1+1)*t*t*t - backC1*t*t }
+
+// EaseOutBack overshoots slightly before settling.
+func EaseOutBack(t float64) float64 { return 1 - EaseInBack(1-t) }
+
+// EaseInOutBack pulls back at the start and overshoots at the end.
+func EaseInOutBack(t float64) float64 {
+	if t < 0.5 {
+		return EaseInBack(2*t) / 2
+	}
+	return 1 - EaseInBack(2-2*t)/2
+}
+
+// EaseInElastic winds up like a spring before moving.
+func EaseInElastic(t float64) float64 { return 1 - EaseOutElastic(1-t) }
+
+// EaseOutElastic overshoots and oscillates like a spring.
+func EaseOutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*2*math.Pi/3) + 1
+}
+
+// EaseOutBounce bounces like a dropped ball.
+func EaseOutBounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// Tween interpolates between two node trees. Nodes of a [Slice] are matched
+// by index and nodes of a [Map] by key; other nodes are interpolated by their
+// bounds. Nodes only in To appear by growing from the anchor point (AX, AY)
+// of their bounds, and nodes only in From disappear by shrinking into it.
+type Tween[S ng.Scalar] struct {
+	From, To Node[S]
+	// Ease eases the progress. Nil means EaseLinear.
+	Ease Ease
+	// AX and AY is the anchor that appearing and disappearing nodes grow
+	// from and shrink into.
+	AX, AY float64
+}
+
+// At returns a new node tree at the progress p in [0, 1]. It is made of
+// [*Rect], [Slice] and [Map] nodes. At 1, disappeared nodes are left out.
+// Easing does not affect when they are left out, even if it overshoots.
+func (tw Tween[S]) At(p float64) Node[S] {
+	t := p
+	if tw.Ease != nil {
+		t = tw.Ease(p)
+	}
+	return tw.at(tw.From, tw.To, p, t)
+}
+
+// at interpolates from and to at the eased progress t. The linear progress p
+// decides whether disappearing nodes are left out.
+func (tw Tween[S]) at(from, to Node[S], p, t float64) Node[S] {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		return tw.at(tw.collapse(to), to, p, t)
+	case to == nil:
+		return tw.at(from, tw.collapse(from), p, t)
+	}
+
+	switch f := from.(type) {
+	case Slice[S]:
+		if g, ok := to.(Slice[S]); ok {
+			s := make(Slice[S], 0, max(len(f), len(g)))
+			for i := range max(len(f), len(g)) {
+				var a, b Node[S]
+				if i < len(f) {
+					a = f[i]
+				}
+				if i < len(g) {
+					b = g[i]
+				}
+				if b == nil && p >= 1 {
+					continue
+				}
+				s = append(s, tw.at(a, b, p, t))
+			}
+			return s
+		}
+	case Map[S]:
+		if g, ok := to.(Map[S]); ok {
+			m := Map[S]{}
+			for k, a := range f {
+				if b, ok := g[k]; ok {
+					m[k] = tw.at(a, b, p, t)
+				} else if p < 1 {
+					m[k] = tw.at(a, nil, p, t)
+				}
+			}
+			for k, b := range g {
+				if _, ok := f[k]; !ok {
+					m[k] = tw.at(nil, b, p, t)
+				}
+			}
+			return m
+		}
+	}
+	return from.Bounds().Lerp(to.Bounds(), t)
+}
+
+// collapse returns a copy of n with every rectangle shrunk into the anchor
+// point of its bounds.
+func (tw Tween[S]) collapse(n Node[S]) Node[S] {
+	switch n := n.(type) {
+	case Slice[S]:
+		s := slices.Clone(n)
+		for i, c := range s {
+			s[i] = tw.collapse(c)
+		}
+		return s
+	case Map[S]:
+		m := Map[S]{}
+		for k, c := range n {
+			m[k] = tw.collapse(c)
+		}
+		return m
+	}
+	p := n.Bounds().Anchor(tw.AX, tw.AY)
+	return &Rect[S]{p, p}
+}
@@ -0,0 +1,94 @@
+package align
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLerp(t *testing.T) {
+	a, b := XYWH(0, 0, 10, 10), XYWH(10, 20, 30, 10)
+	if got, want := a.Lerp(b, .5), XYWH(5, 10, 20, 10); !got.Eq(want) {
+		t.Errorf("Lerp(.5) = %v, want %v", got, want)
+	}
+	if got, want := XY(0, 0).Lerp(XY(3, 5), .5), XY(2, 2); !got.Eq(want) {
+		t.Errorf("Point Lerp rounds half to even: got %v, want %v", got, want)
+	}
+}
+
+func TestTween(t *testing.T) {
+	closed := Map[int]{
+		"menu":  Slice[int]{XYWH(0, 0, 10, 10)},
+		"title": XYWH(0, 0, 100, 20),
+		"hint":  XYWH(0, 90, 20, 10),
+	}
+	open := Map[int]{
+		"menu":  Slice[int]{XYWH(0, 0, 40, 10), XYWH(0, 20, 40, 10)},
+		"title": XYWH(0, 20, 100, 20),
+	}
+	tw := Tween[int]{From: closed, To: open, AX: .5, AY: .5}
+
+	mid := tw.At(.5).(Map[int])
+	tests := []struct {
+		name      string
+		got, want *Rect[int]
+	}{
+		{name: "matched", got: mid["title"].Bounds(), want: XYWH(0, 10, 100, 20)},
+		{name: "matched in slice", got: mid["menu"].(Slice[int])[0].Bounds(), want: XYWH(0, 0, 25, 10)},
+		{name: "appearing", got: mid["menu"].(Slice[int])[1].Bounds(), want: XYXY(10, 22, 30, 28)},
+		{name: "disappearing", got: mid["hint"].Bounds(), want: XYWH(5, 92, 10, 6)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Eq(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	end := tw.At(1).(Map[int])
+	if _, ok := end["hint"]; ok {
+		t.Error("disappeared node is present at 1")
+	}
+}
+
+func TestTweenOvershoot(t *testing.T) {
+	tw := Tween[int]{
+		From: Map[int]{"hint": XYWH(0, 0, 10, 10), "menu": Slice[int]{XYWH(0, 0, 10, 10), XYWH(0, 20, 10, 10)}},
+		To:   Map[int]{"menu": Slice[int]{XYWH(0, 0, 10, 10)}},
+		Ease: EaseOutBack,
+	}
+	for _, p := range []float64{.5, .7, .9, .99} {
+		m := tw.At(p).(Map[int])
+		if _, ok := m["hint"]; !ok {
+			t.Errorf("disappearing node in map is missing at %v", p)
+		}
+		if got := len(m["menu"].(Slice[int])); got != 2 {
+			t.Errorf("len(menu) = %d at %v, want 2", got, p)
+		}
+	}
+	m := tw.At(1).(Map[int])
+	if _, ok := m["hint"]; ok {
+		t.Error("disappeared node in map is present at 1")
+	}
+	if got := len(m["menu"].(Slice[int])); got != 1 {
+		t.Errorf("len(menu) = %d at 1, want 1", got)
+	}
+}
+
+func TestEase(t *testing.T) {
+	eases := map[string]Ease{
+		"Linear": EaseLinear, "InQuad": EaseInQuad, "OutQuad": EaseOutQuad,
+		"InOutQuad": EaseInOutQuad, "InCubic": EaseInCubic, "OutCubic": EaseOutCubic,
+		"InOutCubic": EaseInOutCubic, "InBack": EaseInBack, "OutBack": EaseOutBack,
+		"InOutBack": EaseInOutBack, "InElastic": EaseInElastic, "OutElastic": EaseOutElastic,
+		"OutBounce": EaseOutBounce,
+	}
+	for name, e := range eases {
+		if got := e(0); math.Abs(got) > 1e-9 {
+			t.Errorf("%s(0) = %v, want 0", name, got)
+		}
+		if got := e(1); math.Abs(got-1) > 1e-9 {
+			t.Errorf("%s(1) = %v, want 1", name, got)
+		}
+	}
+}
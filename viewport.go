@@ -0,0 +1,175 @@
+package align
+
+import (
+	"math"
+
+	"github.com/eihigh/ng"
+)
+
+// ScaleMode specifies how a [Viewport] maps its design space onto the screen.
+type ScaleMode int
+
+const (
+	// ScaleFit scales uniformly so that the whole design space is visible,
+	// leaving letterbox bars on the longer axis of the screen.
+	ScaleFit ScaleMode = iota
+	// ScaleFill scales uniformly so that the design space covers the whole
+	// screen, cropping the overflowing part.
+	ScaleFill
+	// ScaleStretch scales each axis independently to match the screen.
+	ScaleStretch
+	// ScaleInteger is like ScaleFit but only uses whole-number scale factors.
+	// It falls back to ScaleFit when the screen is smaller than the design.
+	ScaleInteger
+	// ScaleExpand is like ScaleFit but expands the design space along its
+	// shorter axis instead of leaving letterbox bars.
+	ScaleExpand
+)
+
+// Viewport maps a design-space rectangle onto a screen rectangle.
+type Viewport[S ng.Scalar] struct {
+	design  *Rect[S]
+	mode    ScaleMode
+	screen  *Rect[S]
+	space   *Rect[S] // effective design space, enlarged in ScaleExpand mode
+	content *Rect[S] // space mapped onto the screen
+	scale   Point[float64]
+}
+
+// NewViewport creates a Viewport that maps design onto screen using mode.
+func NewViewport[S ng.Scalar](design, screen *Rect[S], mode ScaleMode) *Viewport[S] {
+	v := &Viewport[S]{
+		design: design.Clone(),
+		mode:   mode,
+	}
+	v.Resize(screen)
+	return v
+}
+
+// Resize recomputes the mapping for a new screen rectangle.
+func (v *Viewport[S]) Resize(screen *Rect[S]) {
+	v.screen = screen.Clone()
+	dw, dh := float64(v.design.Dx()), float64(v.design.Dy())
+	sw, sh := float64(screen.Dx()), float64(screen.Dy())
+	sx, sy := 1.0, 1.0
+	if dw > 0 {
+		sx = sw / dw
+	}
+	if dh > 0 {
+		sy = sh / dh
+	}
+
+	v.space = v.design.Clone()
+	switch v.mode {
+	case ScaleFit:
+		sx = min(sx, sy)
+		sy = sx
+	case ScaleFill:
+		sx = max(sx, sy)
+		sy = sx
+	case ScaleStretch:
+	case ScaleInteger:
+		s := min(sx, sy)
+		if s >= 1 {
+			s = math.Floor(s)
+		}
+		sx, sy = s, s
+	case ScaleExpand:
+		s := min(sx, sy)
+		sx, sy = s, s
+		if s > 0 {
+			// Round up for integer types so that no bar is left.
+			v.space = WH(roundTo[S](sw/s, RoundCeil), roundTo[S](sh/s, RoundCeil)).CenterOf(v.design)
+		}
+	}
+	v.scale = Point[float64]{sx, sy}
+
+	w := S(float64(v.space.Dx()) * sx)
+	h := S(float64(v.space.Dy()) * sy)
+	if v.mode == ScaleExpand {
+		// Truncation must not uncover the screen edges.
+		w, h = max(w, screen.Dx()), max(h, screen.Dy())
+	}
+	v.content = WH(w, h).CenterOf(screen)
+}
+
+// Mode returns the scale mode of v.
+func (v *Viewport[S]) Mode() ScaleMode {
+	return v.mode
+}
+
+// Scale returns the horizontal and vertical scale factors from design space
+// to screen space.
+func (v *Viewport[S]) Scale() Point[float64] {
+	return v.scale
+}
+
+// Screen returns a copy of the screen rectangle.
+func (v *Viewport[S]) Screen() *Rect[S] {
+	return v.screen.Clone()
+}
+
+// Design returns a copy of the effective design space. It differs from the
+// rectangle passed to [NewViewport] only in ScaleExpand mode.
+func (v *Viewport[S]) Design() *Rect[S] {
+	return v.space.Clone()
+}
+
+// Content returns the screen rectangle that the design space is mapped onto.
+// In ScaleFill mode it extends beyond the screen.
+func (v *Viewport[S]) Content() *Rect[S] {
+	return v.content.Clone()
+}
+
+// Bars returns the letterbox bars, the parts of the screen not covered by
+// the content. Empty bars are omitted.
+func (v *Viewport[S]) Bars() Slice[S] {
+	sc, c := v.screen, v.content
+	y0 := max(sc.Min.Y, min(sc.Max.Y, c.Min.Y))
+	y1 := max(sc.Min.Y, min(sc.Max.Y, c.Max.Y))
+	x0 := max(sc.Min.X, min(sc.Max.X, c.Min.X))
+	x1 := max(sc.Min.X, min(sc.Max.X, c.Max.X))
+	bars := Slice[S]{}
+	for _, r := range []*Rect[S]{
+		XYXY(sc.Min.X, sc.Min.Y, sc.Max.X, y0), // top
+		XYXY(sc.Min.X, y1, sc.Max.X, sc.Max.Y), // bottom
+		XYXY(sc.Min.X, y0, x0, y1),             // left
+		XYXY(x1, y0, sc.Max.X, y1),             // right
+	} {
+		if !r.Empty() {
+			bars = append(bars, r)
+		}
+	}
+	return bars
+}
+
+// ToScreen maps the design-space point p to screen space.
+func (v *Viewport[S]) ToScreen(p Point[S]) Point[S] {
+	return Point[S]{
+		X: v.content.Min.X + S(float64(p.X-v.space.Min.X)*v.scale.X),
+		Y: v.content.Min.Y + S(float64(p.Y-v.space.Min.Y)*v.scale.Y),
+	}
+}
+
+// ToDesign maps the screen-space point p to design space. It is the inverse
+// of [Viewport.ToScreen] and is typically used for pointer input.
+func (v *Viewport[S]) ToDesign(p Point[S]) Point[S] {
+	sx, sy := v.scale.X, v.scale.Y
+	if sx == 0 || sy == 0 {
+		return v.space.Min
+	}
+	return Point[S]{
+		X: v.space.Min.X + S(float64(p.X-v.content.Min.X)/sx),
+		Y: v.space.Min.Y + S(float64(p.Y-v.content.Min.Y)/sy),
+	}
+}
+
+// RectToScreen maps the design-space rectangle r to screen space.
+func (v *Viewport[S]) RectToScreen(r *Rect[S]) *Rect[S] {
+	return &Rect[S]{v.ToScreen(r.Min), v.ToScreen(r.Max)}
+}
+
+// RectToDesign maps the screen-space rectangle r to design space.
+func (v *Viewport[S]) RectToDesign(r *Rect[S]) *Rect[S] {
+	return &Rect[S]{v.ToDesign(r.Min), v.ToDesign(r.Max)}
+}
@@ -0,0 +1,75 @@
+package align
+
+import "testing"
+
+func TestViewport(t *testing.T) {
+	design := WH(320, 180)
+	tests := []struct {
+		name    string
+		screen  *Rect[int]
+		mode    ScaleMode
+		content *Rect[int]
+		bars    int
+	}{
+		{
+			name:    "Fit pillarbox",
+			screen:  WH(800, 360),
+			mode:    ScaleFit,
+			content: XYWH(80, 0, 640, 360),
+			bars:    2,
+		},
+		{
+			name:    "Fill",
+			screen:  WH(800, 360),
+			mode:    ScaleFill,
+			content: XYWH(0, -45, 800, 450),
+			bars:    0,
+		},
+		{
+			name:    "Stretch",
+			screen:  WH(800, 360),
+			mode:    ScaleStretch,
+			content: XYWH(0, 0, 800, 360),
+			bars:    0,
+		},
+		{
+			name:    "Integer",
+			screen:  WH(1000, 600),
+			mode:    ScaleInteger,
+			content: XYWH(20, 30, 960, 540),
+			bars:    4,
+		},
+		{
+			name:    "Expand",
+			screen:  WH(800, 360),
+			mode:    ScaleExpand,
+			content: XYWH(0, 0, 800, 360),
+			bars:    0,
+		},
+		{
+			name:    "Expand odd size",
+			screen:  WH(801, 361),
+			mode:    ScaleExpand,
+			content: XYXY(-1, 0, 801, 361),
+			bars:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewViewport(design, tt.screen, tt.mode)
+			if got := v.Content(); !got.Eq(tt.content) {
+				t.Errorf("Content() = %v, want %v", got, tt.content)
+			}
+			if got := len(v.Bars()); got != tt.bars {
+				t.Errorf("len(Bars()) = %d, want %d", got, tt.bars)
+			}
+			// Truncation may lose up to one unit with fractional scales.
+			p := XY(100, 50)
+			got := v.ToDesign(v.ToScreen(p))
+			if d := got.Sub(p); d.X < -1 || d.X > 1 || d.Y < -1 || d.Y > 1 {
+				t.Errorf("ToDesign(ToScreen(%v)) = %v", p, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package align
+
+import (
+	"iter"
+	"sort"
+
+	"github.com/eihigh/ng"
+)
+
+// VirtualList computes the rectangles of a vertical list on demand instead
+// of allocating one per item. A list of fixed-height items is laid out like
+// [Rect.RepeatY]; variable heights are summed into a cached prefix sum.
+type VirtualList[S ng.Scalar] struct {
+	first  Rect[S]
+	n      int
+	gap    S
+	height func(i int) S // nil for fixed-height items
+	prefix []S           // prefix[i] is the top of item i relative to first.Min.Y
+	valid  int           // prefix[:valid+1] is up to date
+}
+
+// NewVirtualList creates a list of n items of the size of first, with the
+// first item at first and gap spacing between items.
+func NewVirtualList[S ng.Scalar](first *Rect[S], n int, gap S) *VirtualList[S] {
+	return &VirtualList[S]{first: *first, n: n, gap: gap}
+}
+
+// NewVirtualListFunc creates a list of n items whose heights are given by
+// height. The items span first horizontally and start at its top.
+func NewVirtualListFunc[S ng.Scalar](first *Rect[S], n int, gap S, height func(i int) S) *VirtualList[S] {
+	return &VirtualList[S]{first: *first, n: n, gap: gap, height: height, prefix: []S{0}}
+}
+
+// Len returns the number of items.
+func (l *VirtualList[S]) Len() int {
+	return l.n
+}
+
+// SetLen changes the number of items.
+func (l *VirtualList[S]) SetLen(n int) {
+	l.n = n
+	l.Invalidate(n)
+}
+
+// Invalidate discards the cached heights from item i onwards. Call it when
+// the height of item i changes.
+func (l *VirtualList[S]) Invalidate(i int) {
+	l.valid = max(0, min(l.valid, i))
+}
+
+// top returns the top of item i, for 0 <= i <= n, relative to the list.
+func (l *VirtualList[S]) top(i int) S {
+	if l.height == nil {
+		return S(i) * (l.first.Dy() + l.gap)
+	}
+	if len(l.prefix) < l.n+1 {
+		l.prefix = append(l.prefix, make([]S, l.n+1-len(l.prefix))...)
+	}
+	for ; l.valid < i; l.valid++ {
+		l.prefix[l.valid+1] = l.prefix[l.valid] + l.height(l.valid) + l.gap
+	}
+	return l.prefix[i]
+}
+
+// Item returns the rectangle of item i.
+func (l *VirtualList[S]) Item(i int) Rect[S] {
+	y := l.first.Min.Y + l.top(i)
+	h := l.first.Dy()
+	if l.height != nil {
+		h = l.height(i)
+	}
+	return Rect[S]{Point[S]{l.first.Min.X, y}, Point[S]{l.first.Max.X, y + h}}
+}
+
+// Height returns the height of the whole list.
+func (l *VirtualList[S]) Height() S {
+	if l.n == 0 {
+		return 0
+	}
+	return l.top(l.n) - l.gap
+}
+
+// Bounds returns the bounding rectangle of the whole list.
+func (l *VirtualList[S]) Bounds() *Rect[S] {
+	return XYWH(l.first.Min.X, l.first.Min.Y, l.first.Dx(), l.Height())
+}
+
+// IndexAt returns the index of the item at the vertical offset y from the
+// top of the list, clamped to [0, Len-1]. Offsets in a gap map to the item
+// above it. It returns -1 for an empty list.
+func (l *VirtualList[S]) IndexAt(y S) int {
+	if l.n == 0 {
+		return -1
+	}
+	i := sort.Search(l.n, func(i int) bool { return l.top(i+1) > y })
+	return min(i, l.n-1)
+}
+
+// Visible returns an iterator over the items overlapping view.
+func (l *VirtualList[S]) Visible(view *Rect[S]) iter.Seq2[int, Rect[S]] {
+	return func(yield func(int, Rect[S]) bool) {
+		if l.n == 0 || view.Max.Y <= l.first.Min.Y {
+			return
+		}
+		i := 0
+		if view.Min.Y > l.first.Min.Y {
+			i = l.IndexAt(view.Min.Y - l.first.Min.Y)
+		}
+		for ; i < l.n; i++ {
+			r := l.Item(i)
+			if r.Min.Y >= view.Max.Y {
+				return
+			}
+			if r.Overlaps(view) && !yield(i, r) {
+				return
+			}
+		}
+	}
+}
+
+// VirtualGrid computes the rectangles of a grid of equally sized items on
+// demand, laid out in row-major order like [Rect.Repeat].
+type VirtualGrid[S ng.Scalar] struct {
+	first      Rect[S]
+	n, cols    int
+	xGap, yGap S
+}
+
+// NewVirtualGrid creates a grid of n items of the size of first in cols
+// columns, with the first item at first.
+func NewVirtualGrid[S ng.Scalar](first *Rect[S], n, cols int, xGap, yGap S) *VirtualGrid[S] {
+	return &VirtualGrid[S]{first: *first, n: n, cols: max(1, cols), xGap: xGap, yGap: yGap}
+}
+
+// Len returns the number of items.
+func (g *VirtualGrid[S]) Len() int {
+	return g.n
+}
+
+// SetLen changes the number of items.
+func (g *VirtualGrid[S]) SetLen(n int) {
+	g.n = n
+}
+
+// rows returns the number of rows.
+func (g *VirtualGrid[S]) rows() int {
+	return (g.n + g.cols - 1) / g.cols
+}
+
+// Item returns the rectangle of item i.
+func (g *VirtualGrid[S]) Item(i int) Rect[S] {
+	x := S(i%g.cols) * (g.first.Dx() + g.xGap)
+	y := S(i/g.cols) * (g.first.Dy() + g.yGap)
+	return *g.first.Clone().Add(Point[S]{x, y})
+}
+
+// Bounds returns the bounding rectangle of the whole grid.
+func (g *VirtualGrid[S]) Bounds() *Rect[S] {
+	if g.n == 0 {
+		return XYWH(g.first.Min.X, g.first.Min.Y, 0, 0)
+	}
+	cols, rows := S(min(g.n, g.cols)), S(g.rows())
+	w := cols*(g.first.Dx()+g.xGap) - g.xGap
+	h := rows*(g.first.Dy()+g.yGap) - g.yGap
+	return XYWH(g.first.Min.X, g.first.Min.Y, w, h)
+}
+
+// IndexAt returns the index of the item at p, or -1 if p is outside every
+// item.
+func (g *VirtualGrid[S]) IndexAt(p Point[S]) int {
+	col, row := g.cell(p)
+	if col < 0 || col >= g.cols || row < 0 {
+		return -1
+	}
+	i := row*g.cols + col
+	if i >= g.n {
+		return -1
+	}
+	if r := g.Item(i); !p.In(r) {
+		return -1
+	}
+	return i
+}
+
+// cell returns the column and row of the cell, including the gaps after it,
+// containing p.
+func (g *VirtualGrid[S]) cell(p Point[S]) (col, row int) {
+	d := p.Sub(g.first.Min)
+	cw, ch := g.first.Dx()+g.xGap, g.first.Dy()+g.yGap
+	col, row = -1, -1
+	if d.X >= 0 && cw > 0 {
+		col = int(d.X / cw)
+	}
+	if d.Y >= 0 && ch > 0 {
+		row = int(d.Y / ch)
+	}
+	return col, row
+}
+
+// Visible returns an iterator over the items overlapping view.
+func (g *VirtualGrid[S]) Visible(view *Rect[S]) iter.Seq2[int, Rect[S]] {
+	return func(yield func(int, Rect[S]) bool) {
+		if g.n == 0 {
+			return
+		}
+		c0, r0 := g.cell(view.Min)
+		c1, r1 := g.cell(view.Max)
+		if view.Max.X <= g.first.Min.X || view.Max.Y <= g.first.Min.Y {
+			return
+		}
+		c0, r0 = max(0, c0), max(0, r0)
+		c1, r1 = min(g.cols-1, c1), min(g.rows()-1, r1)
+		for row := r0; row <= r1; row++ {
+			for col := c0; col <= c1; col++ {
+				i := row*g.cols + col
+				if i >= g.n {
+					return
+				}
+				r := g.Item(i)
+				if r.Overlaps(view) && !yield(i, r) {
+					return
+				}
+			}
+		}
+	}
+}
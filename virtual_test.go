@@ -0,0 +1,79 @@
+package align
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVirtualList(t *testing.T) {
+	first := XYWH(0, 0, 50, 10)
+	fixed := NewVirtualList(first, 100000, 2)
+	want := first.RepeatY(5, 2)
+	for i, w := range want {
+		if got := fixed.Item(i); !got.Eq(w.Bounds()) {
+			t.Errorf("fixed Item(%d) = %v, want %v", i, &got, w)
+		}
+	}
+	if got, want := fixed.Height(), 100000*12-2; got != want {
+		t.Errorf("fixed Height() = %d, want %d", got, want)
+	}
+
+	variable := NewVirtualListFunc(first, 100000, 0, func(i int) int { return 10 + i%3*5 })
+	// Heights are 10, 15, 20, 10, 15, 20, ... so items 3k start at 45k.
+	tests := []struct {
+		name      string
+		list      *VirtualList[int]
+		view      *Rect[int]
+		want      []int
+		indexAtY  int
+		wantIndex int
+	}{
+		{name: "fixed", list: fixed, view: XYWH(0, 120000, 50, 30), want: []int{10000, 10001, 10002}, indexAtY: 23, wantIndex: 1},
+		{name: "variable", list: variable, view: XYWH(0, 450, 50, 30), want: []int{30, 31, 32}, indexAtY: 24, wantIndex: 1},
+		{name: "outside", list: variable, view: XYWH(60, 0, 50, 30), want: nil, indexAtY: -5, wantIndex: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for i, r := range tt.list.Visible(tt.view) {
+				if !r.Overlaps(tt.view) {
+					t.Errorf("item %d = %v does not overlap the view", i, &r)
+				}
+				got = append(got, i)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Visible() = %v, want %v", got, tt.want)
+			}
+			if got := tt.list.IndexAt(tt.indexAtY); got != tt.wantIndex {
+				t.Errorf("IndexAt(%d) = %d, want %d", tt.indexAtY, got, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestVirtualGrid(t *testing.T) {
+	first := XYWH(10, 10, 20, 20)
+	g := NewVirtualGrid(first, 10, 4, 5, 5)
+	want := first.Repeat(4, 3, 5, 5)
+	for i := range g.Len() {
+		if got := g.Item(i); !got.Eq(want[i].Bounds()) {
+			t.Errorf("Item(%d) = %v, want %v", i, &got, want[i])
+		}
+	}
+	if got, want := g.Bounds(), XYWH(10, 10, 95, 70); !got.Eq(want) {
+		t.Errorf("Bounds() = %v, want %v", got, want)
+	}
+	var got []int
+	for i := range g.Visible(XYXY(40, 40, 70, 80)) {
+		got = append(got, i)
+	}
+	if want := []int{5, 6, 9}; !slices.Equal(got, want) {
+		t.Errorf("Visible() = %v, want %v", got, want)
+	}
+	if got := g.IndexAt(XY(36, 61)); got != 9 {
+		t.Errorf("IndexAt in item = %d, want 9", got)
+	}
+	if got := g.IndexAt(XY(32, 61)); got != -1 {
+		t.Errorf("IndexAt in gap = %d, want -1", got)
+	}
+}
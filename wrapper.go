@@ -9,6 +9,7 @@ type Wrapper[S ng.Scalar] struct {
 	stack, wrap func(a, b *Rect[S])
 	s           Slice[S]
 	lineFirst   *Rect[S]
+	lines       []int // index of the first node of each line
 }
 
 // NewWrapper creates a Wrapper that arranges rectangles within bounds.
@@ -32,6 +33,7 @@ func (w *Wrapper[S]) Add(r *Rect[S]) (ok bool) {
 			return false
 		}
 		w.lineFirst = r
+		w.lines = append(w.lines, 0)
 	} else {
 		w.stack(r, w.s.Last().Bounds())
 		if !r.In(w.bounds) {
@@ -40,11 +42,41 @@ func (w *Wrapper[S]) Add(r *Rect[S]) (ok bool) {
 				return false
 			}
 			w.lineFirst = r
+			w.lines = append(w.lines, len(w.s))
 		}
 	}
 	w.s = append(w.s, r)
 	return true
 }
 
+// AddWithBaseline is like [Wrapper.Add] but gives r a baseline at ascent
+// below its top for [Wrapper.AlignBaselines]. The slice returned by
+// [Wrapper.Slice] holds r as a [*Baselined].
+func (w *Wrapper[S]) AddWithBaseline(r *Rect[S], ascent S) (ok bool) {
+	if !w.Add(r) {
+		return false
+	}
+	w.s[len(w.s)-1] = WithBaseline[S](r, ascent)
+	return true
+}
+
+// AlignBaselines aligns the nodes of each line to a shared baseline as with
+// [Slice.AlignBaselines]. Lines that grow taller push the following lines
+// down, assuming that lines wrap downwards.
+func (w *Wrapper[S]) AlignBaselines() {
+	var push S
+	for i, start := range w.lines {
+		end := len(w.s)
+		if i+1 < len(w.lines) {
+			end = w.lines[i+1]
+		}
+		line := w.s[start:end]
+		line.Shift(Point[S]{0, push})
+		bottom := line.Bounds().Max.Y
+		line.AlignBaselines()
+		push += line.Bounds().Max.Y - bottom
+	}
+}
+
 // Slice returns all rectangles added to the wrapper.
 func (w *Wrapper[S]) Slice() Slice[S] { return w.s }